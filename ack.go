@@ -0,0 +1,171 @@
+package signals
+
+import (
+	"context"
+	"sync"
+)
+
+// Ack reports that a listener finished processing an emission of
+// EmitWithAck.
+type Ack struct {
+	// Key is the key of the listener. A listener added without a key has a
+	// negative key generated by the package.
+	Key SignalType
+
+	// Err is the error of the listener, the *PanicError of a listener that
+	// panicked, or nil if the listener succeeded.
+	Err error
+}
+
+// ackStream collects the acks of the listeners invoked by an emission of
+// EmitWithAck, and forwards them to the channel returned to the caller
+// without ever blocking the listeners.
+type ackStream struct {
+	// started is closed when the emission is dispatched to the listeners.
+	started chan struct{}
+
+	mu     sync.Mutex
+	queue  []Ack
+	done   bool
+	notify chan struct{}
+}
+
+// newAckStream creates an ackStream for a new emission.
+func newAckStream() *ackStream {
+	return &ackStream{
+		started: make(chan struct{}),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// start records that the emission is dispatched to the listeners.
+func (a *ackStream) start() {
+	close(a.started)
+}
+
+// post queues the ack of a listener.
+func (a *ackStream) post(ack Ack) {
+	a.mu.Lock()
+	if a.done {
+		a.mu.Unlock()
+		return
+	}
+	a.queue = append(a.queue, ack)
+	a.mu.Unlock()
+
+	a.wake()
+}
+
+// finish records that all the listeners of the emission returned.
+func (a *ackStream) finish() {
+	a.mu.Lock()
+	a.done = true
+	a.mu.Unlock()
+
+	a.wake()
+}
+
+// wake notifies forward that the stream changed.
+func (a *ackStream) wake() {
+	select {
+	case a.notify <- struct{}{}:
+	default:
+	}
+}
+
+// forward sends the acks to out in the order they were posted, and closes out
+// once all the listeners returned and their acks were sent, or once the
+// context is done.
+func (a *ackStream) forward(ctx context.Context, out chan<- Ack) {
+	defer close(out)
+
+	for {
+		a.mu.Lock()
+		queue, done := a.queue, a.done
+		a.queue = nil
+		a.mu.Unlock()
+
+		for _, ack := range queue {
+			select {
+			case out <- ack:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if len(queue) > 0 {
+			continue
+		}
+		if done {
+			return
+		}
+
+		select {
+		case <-a.notify:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// EmitWithAck emits the payload like Emit, without waiting for the listeners,
+// and returns a channel receiving an Ack from each listener as soon as it
+// returns, so the caller can track the progress of long running listeners.
+// The channel is closed once all the listeners returned and their acks were
+// received, or once the context is done, in which case the acks of the
+// listeners still running are not sent. The caller must receive from the
+// channel until it is closed, or cancel the context.
+//
+// If the emission fails before reaching the listeners, for instance with
+// ErrSignalClosed or ErrGated, EmitWithAck returns the error and a closed
+// channel. A value queued by a signal created with WithBuffer, or held by a
+// paused signal, is delivered after EmitWithAck returned, so the channel is
+// closed right away and receives no ack. A listener whose invocation is
+// skipped, such as a once listener that already fired, still sends an ack
+// with a nil error.
+//
+// Example:
+//
+//	acks, err := signal.EmitWithAck(ctx, job)
+//	if err != nil {
+//		return err
+//	}
+//	for ack := range acks {
+//		log.Printf("listener %d done: %v", ack.Key, ack.Err)
+//	}
+func (s *BaseSignal[T]) EmitWithAck(ctx context.Context, payload T) (<-chan Ack, error) {
+	acks := newAckStream()
+
+	// The emission is registered before the goroutine starts, so a Wait that
+	// follows EmitWithAck always waits for it.
+	s.activity.hold()
+	done := make(chan error, 1)
+	go func() {
+		defer s.activity.end()
+		err := s.emitWith(ctx, s.impl, emission[T]{payload: payload, acks: acks})
+		acks.finish()
+		done <- err
+	}()
+
+	select {
+	case <-acks.started:
+	case err := <-done:
+		select {
+		case <-acks.started:
+		default:
+			return closedAcks(), err
+		}
+	}
+	out := make(chan Ack)
+	go acks.forward(ctx, out)
+
+	return out, nil
+}
+
+// closedAcks returns a closed channel of acks, for the emissions that do not
+// reach the listeners right away.
+func closedAcks() <-chan Ack {
+	out := make(chan Ack)
+	close(out)
+
+	return out
+}
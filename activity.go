@@ -0,0 +1,143 @@
+package signals
+
+import (
+	"context"
+	"sync"
+)
+
+// activity counts the emissions in progress of a signal and records whether
+// the signal was closed, so Close and Wait can coordinate with Emit.
+type activity struct {
+	mu      sync.Mutex
+	running int
+	idle    chan struct{}
+	closed  bool
+
+	// finished, if set, is closed once the work done after the emissions
+	// drained at close, such as the close listeners, is finished.
+	finished chan struct{}
+}
+
+// begin registers a new emission. It returns false if the signal is closed,
+// in which case the emission must not start.
+func (a *activity) begin() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return false
+	}
+
+	if a.running == 0 {
+		a.idle = make(chan struct{})
+	}
+	a.running++
+
+	return true
+}
+
+// hold registers work that belongs to an emission already registered with
+// begin but outlives it, such as a buffered value. Unlike begin, it succeeds
+// even if the signal was closed in the meantime. The work is finished by end.
+func (a *activity) hold() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.running == 0 {
+		a.idle = make(chan struct{})
+	}
+	a.running++
+}
+
+// end marks an emission registered with begin, or work registered with hold,
+// as finished.
+func (a *activity) end() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.running--
+	if a.running == 0 {
+		close(a.idle)
+	}
+}
+
+// close prevents further emissions from starting. It returns false if the
+// signal was already closed.
+func (a *activity) close() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return false
+	}
+	a.closed = true
+
+	return true
+}
+
+// isClosed reports whether the signal was closed.
+func (a *activity) isClosed() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.closed
+}
+
+// closeThen closes like close, and calls fn in a new goroutine once the
+// emissions in progress finished. Wait does not return before fn returned.
+// It returns false, without calling fn, if the signal was already closed.
+func (a *activity) closeThen(fn func()) bool {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return false
+	}
+	a.closed = true
+	finished := make(chan struct{})
+	a.finished = finished
+	a.mu.Unlock()
+
+	go func() {
+		defer close(finished)
+		_ = a.drain(context.Background())
+		fn()
+	}()
+
+	return true
+}
+
+// wait blocks until no emission is in progress, and the work registered with
+// closeThen is finished, or until the context is done.
+func (a *activity) wait(ctx context.Context) error {
+	if err := a.drain(ctx); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	finished := a.finished
+	a.mu.Unlock()
+	if finished == nil {
+		return nil
+	}
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drain blocks until no emission is in progress or the context is done.
+func (a *activity) drain(ctx context.Context) error {
+	a.mu.Lock()
+	if a.running == 0 {
+		a.mu.Unlock()
+		return nil
+	}
+	idle := a.idle
+	a.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -2,107 +2,1447 @@ package signals
 
 import (
 	"context"
-	"errors"
+	"math"
+	"reflect"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// SignalType is the key identifying a listener of a signal. Any value can be
+// chosen as a key, including a negative one. The keys generated by the
+// package for the listeners added without a key, and the keys returned by
+// KeyOf, are taken from the lowest values of SignalType, far below the keys
+// chosen by hand.
 type SignalType int
 
-// keyedListener represents a combination of a listener and an optional key used for identification.
+// generatedKeys is the source of the keys returned by generateKey.
+var generatedKeys atomic.Int64
+
+// generateKey returns a new, unique, negative key, counted up from the lowest
+// SignalType.
+func generateKey() SignalType {
+	return SignalType(math.MinInt + int(generatedKeys.Add(1)))
+}
+
+// ListenerLimitReached is returned instead of the number of subscribers by the
+// methods adding a listener when the limit set with WithMaxListeners is
+// reached. The methods return -1 when the key of the listener is already used.
+const ListenerLimitReached = -2
+
+// keyedListener represents a combination of a listener and the key used for
+// identification, which is generated if the listener was added without one.
 type keyedListener[T any] struct {
 	key      SignalType
-	listener SignalListener[T]
+	listener ResultListener[T]
+	priority int
+	timeout  time.Duration
+
+	// generated marks a key generated because the listener was added
+	// without one.
+	generated bool
+
+	// filter, if set, must accept the payload for the listener to be invoked.
+	filter func(T) bool
+
+	// batch is the listener added with AddBatchListener, which listener
+	// adapts to single values.
+	batch BatchListener[T]
+
+	// tags are the groups of the listener set with WithTags.
+	tags []string
+
+	// handler is the object added with AddHandler, which identifies the
+	// listener in the handlers map of the signal.
+	handler Handler[T]
+
+	// bound is the context of a listener added with AddListenerCtx. Once it
+	// is done, the listener is never invoked again.
+	bound context.Context
+
+	// unbind stops the removal of a listener added with AddListenerCtx once
+	// its context is done. It is called when the listener is removed.
+	unbind func() bool
+
+	// once marks a listener that is removed after its first invocation, and
+	// fired records whether that invocation already happened.
+	once  bool
+	fired atomic.Bool
+
+	// queue serializes the invocations of the listener when the signal is
+	// created with WithOrderedDelivery.
+	queue *serialQueue
+
+	// running counts the invocations of the listener in progress.
+	running inflight
+
+	// fn is the code pointer of the function the listener was added with,
+	// compared by WithDedup. It is 0 for the handlers added with AddHandler,
+	// including the ones connecting derived signals to their source, which
+	// WithDedup never compares.
+	fn uintptr
+
+	// breaker is the circuit breaker of the listener when the signal is
+	// created with WithCircuitBreaker.
+	breaker breaker
+
+	// slow counts the consecutive invocations of the listener slower than
+	// the threshold set with WithSlowListenerEviction.
+	slow atomic.Int32
+}
+
+// BaseSignal provides the base implementation of the Signal interface.
+// It is intended to be used as an abstract base for underlying signal mechanisms.
+//
+// Example:
+//
+//	type MyDerivedSignal[T any] struct {
+//		BaseSignal[T]
+//		// Additional fields or methods specific to MyDerivedSignal
+//	}
+//
+//	func (s *MyDerivedSignal[T]) Emit(ctx context.Context, payload T) {
+//		// Custom implementation for emitting the signal
+//	}
+type BaseSignal[T any] struct {
+	mu             sync.RWMutex
+	subscribers    []*keyedListener[T]
+	subscribersMap map[SignalType]*keyedListener[T]
+
+	// count mirrors the length of subscribers, so Len and IsEmpty do not
+	// take the lock.
+	count atomic.Int64
+
+	handlers    map[Handler[T]]*keyedListener[T]
+	middlewares []Middleware[T]
+	config      signalConfig
+
+	// last holds the last emitted value of a signal created with WithReplay,
+	// and emitted records whether there is such a value.
+	last    T
+	emitted bool
+
+	// history holds the last values of a signal created with WithHistory.
+	history history[T]
+
+	// distinct holds the last value delivered by a signal created with
+	// WithEquality.
+	distinct distinct[T]
+
+	// closeListeners are the functions added with AddCloseListener.
+	closeListeners []func(ctx context.Context)
+
+	// gate is the predicate set with WithGate.
+	gate func(ctx context.Context, v T) bool
+
+	// validate is the function set with WithValidator.
+	validate func(v T) error
+
+	// coalesce holds the values emitted recently by a signal created with
+	// WithCoalesce.
+	coalesce coalescing[T]
+
+	// seq is the sequence number of the last emission dispatched to the
+	// listeners.
+	seq atomic.Uint64
+
+	// deltas records whether a listener was added with AddListenerDelta,
+	// from which point the emissions track the previous value, held by
+	// previous and hasPrevious.
+	deltas      atomic.Bool
+	previous    T
+	hasPrevious bool
+
+	activity activity
+
+	// buffer queues the emitted values of a signal created with WithBuffer.
+	buffer *buffer[T]
+
+	pause pauseState[T]
+
+	// limiter bounds the rate of the emissions of a signal created with
+	// WithRateLimit.
+	limiter *limiter
+
+	// stats holds the counters reported by Stats.
+	stats counters
+
+	// impl is the derived type that dispatches the emissions started by the
+	// methods of BaseSignal. It is nil for a bare BaseSignal.
+	impl dispatcher[T]
+}
+
+// dispatcher is implemented by the derived types to invoke the subscribers of
+// an emission.
+type dispatcher[T any] interface {
+	dispatch(ctx context.Context, subscribers []*keyedListener[T], e emission[T]) error
+
+	// reserve takes, without blocking, what the dispatch of the emission to the
+	// subscribers needs to start, and returns the function running that
+	// dispatch. It returns nil if the dispatch would have to wait.
+	reserve(subscribers []*keyedListener[T], e emission[T]) func(context.Context) error
+}
+
+// adder is implemented by the dispatchers that act when a listener is added,
+// such as the one of a QueueSignal delivering its backlog.
+type adder interface {
+	added()
+}
+
+// AddListener adds a listener to the signal. The listener will be called
+// whenever the signal is emitted. It returns the number of subscribers after
+// the listener was added. It accepts an optional key that can be used to
+// remove the listener later or to check if the listener was already added.
+// It returns -1 if the listener with the same key was already added to the
+// signal, or ListenerLimitReached if the signal was created with
+// WithMaxListeners and already has that many listeners. Use AddListenerWith
+// to pass other listener options, such as WithPriority.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	count := signal.AddListener(func(ctx context.Context, payload int) {
+//		// Listener implementation
+//		// ...
+//	}, 1)
+//	fmt.Println("Number of subscribers after adding listener:", count)
+func (s *BaseSignal[T]) AddListener(listener SignalListener[T], key ...SignalType) int {
+	var cfg listenerConfig
+	if len(key) > 0 {
+		cfg.key, cfg.hasKey = key[0], true
+	}
+
+	return s.add(newSubscriber(ignoreResult(listener), cfg).of(listener))
+}
+
+// AddListenerWith adds a listener like AddListener, configured by listener
+// options: a SignalType key, WithPriority to control the invocation order, or
+// any other ListenerOption. It has the same return values as AddListener.
+//
+// Listeners are kept ordered by descending priority. Listeners sharing the same
+// priority keep the order in which they were added.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	count := signal.AddListenerWith(func(ctx context.Context, payload int) {
+//		// Listener implementation
+//		// ...
+//	}, signals.SignalType(1), signals.WithPriority(10))
+//	fmt.Println("Number of subscribers after adding listener:", count)
+func (s *BaseSignal[T]) AddListenerWith(listener SignalListener[T], opts ...ListenerOption) int {
+	return s.add(newSubscriber(ignoreResult(listener), newListenerConfig(opts)).of(listener))
+}
+
+// ListenerID identifies a listener added to a signal. It is the key of the
+// listener: the SignalType given as option, or a negative key generated by the
+// package for a listener added without a key. It can be passed to
+// RemoveListener and HasListener like any key.
+type ListenerID = SignalType
+
+// AddListenerWithID adds a listener like AddListener, and also returns the ID
+// of the listener, which can be used to remove it even if it was added
+// without a key. It returns -1 as count, and does not add the listener, if a
+// listener with the same key was already added to the signal.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	id, _ := signal.AddListenerWithID(func(ctx context.Context, payload int) {
+//		// Listener implementation
+//		// ...
+//	})
+//	signal.RemoveListener(id)
+func (s *BaseSignal[T]) AddListenerWithID(listener SignalListener[T], opts ...ListenerOption) (id ListenerID, count int) {
+	sub := newSubscriber(ignoreResult(listener), newListenerConfig(opts)).of(listener)
+	count = s.add(sub)
+
+	return sub.key, count
+}
+
+// On adds a listener like AddListener and returns a function that removes
+// exactly that listener. The function can be deferred and is safe to call
+// several times, or after the listener was removed by other means such as
+// Reset; it never removes another listener added later with the same key. If
+// a listener with the same key was already added, the listener is not added
+// and the returned function does nothing.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	off := signal.On(func(ctx context.Context, payload int) {
+//		// Listener implementation
+//		// ...
+//	})
+//	defer off()
+func (s *BaseSignal[T]) On(listener SignalListener[T], opts ...ListenerOption) (off func()) {
+	sub := newSubscriber(ignoreResult(listener), newListenerConfig(opts)).of(listener)
+	if s.add(sub) < 0 {
+		return func() {}
+	}
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.subscribersMap[sub.key] == sub {
+			s.delete(sub)
+		}
+	}
+}
+
+// AddListenerCtx adds a listener like AddListener that is removed
+// automatically once the context is done, which ties the listener to the
+// lifetime of its owner. No goroutine waits for the context: the removal is
+// registered with context.AfterFunc, and unregistered if the listener is
+// removed first. If the context is already done, the listener is removed right
+// away.
+//
+// The removal happens shortly after the context is done, but the listener is
+// never invoked once it is: an emission still holding the listener skips it,
+// and removes it if it was not removed yet. Replay does not invoke it either.
+//
+// The bound context only decides whether the listener is invoked. The listener
+// still receives the context of the emission, and the cancellation of that
+// context neither removes the listener nor prevents later emissions from
+// invoking it.
+//
+// Example:
+//
+//	func (w *Widget) Bind(ctx context.Context, changed signals.Signal[Model]) {
+//		changed.AddListenerCtx(ctx, w.render)
+//	}
+func (s *BaseSignal[T]) AddListenerCtx(ctx context.Context, listener SignalListener[T], opts ...ListenerOption) int {
+	sub := newSubscriber(ignoreResult(listener), newListenerConfig(opts)).of(listener)
+	sub.bound = ctx
+	count := s.add(sub)
+	if count < 0 {
+		return count
+	}
+
+	s.bind(sub)
+
+	return count
+}
+
+// bind removes the subscriber once the context it is bound to is done.
+func (s *BaseSignal[T]) bind(sub *keyedListener[T]) {
+	unbind := context.AfterFunc(sub.bound, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.delete(sub)
+	})
+
+	s.mu.Lock()
+	sub.unbind = unbind
+	s.mu.Unlock()
+}
+
+// AddHandler adds the OnSignal method of the handler as a listener of the
+// signal. The handler itself identifies the listener, so it can be removed
+// later with RemoveHandler without keeping a key. It accepts the same options
+// as AddListenerWith and returns the number of subscribers after the handler was
+// added, or -1 if the handler, or a listener with the same key, was already
+// added to the signal. The handler must be comparable, which is the case of a
+// pointer; AddHandler returns -1 for a handler that is not.
+//
+// Example:
+//
+//	type Mailer struct{ /* ... */ }
+//
+//	func (m *Mailer) OnSignal(ctx context.Context, user User) {
+//		// Send the welcome email
+//	}
+//
+//	signal.AddHandler(mailer)
+//	// ...
+//	signal.RemoveHandler(mailer)
+func (s *BaseSignal[T]) AddHandler(h Handler[T], opts ...ListenerOption) int {
+	if h == nil || !reflect.TypeOf(h).Comparable() {
+		return -1
+	}
+
+	sub := newSubscriber(ignoreResult(h.OnSignal), newListenerConfig(opts))
+	sub.handler = h
+
+	return s.add(sub)
+}
+
+// RemoveHandler removes the handler added with AddHandler. It returns the
+// number of subscribers after the handler was removed, or -1 if the handler
+// was not found.
+func (s *BaseSignal[T]) RemoveHandler(h Handler[T]) int {
+	if h == nil || !reflect.TypeOf(h).Comparable() {
+		return -1
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub, ok := s.handlers[h]; ok {
+		s.delete(sub)
+		return len(s.subscribers)
+	}
+
+	return -1
+}
+
+// ReplaceListener swaps the function of the listener with the given key for
+// listener, and returns true. The listener keeps its position and its options,
+// such as its priority, tags or filter. If no listener has the key, the
+// listener is added like AddListener with the key and the given options, and
+// ReplaceListener returns false; the options are ignored otherwise.
+//
+// The replacement is atomic with respect to the emissions: an emission invokes
+// either the old function or the new one, never both nor none, so a handler
+// can be hot-reloaded without the gap of a removal followed by an addition.
+// An emission already in progress may still invoke the old function. A
+// listener added with AddHandler can no longer be removed with RemoveHandler
+// once replaced; it is removed by key.
+//
+// Example:
+//
+//	signal.ReplaceListener(reloaded.Handle, signals.SignalType(1))
+func (s *BaseSignal[T]) ReplaceListener(listener SignalListener[T], key SignalType, opts ...ListenerOption) bool {
+	s.mu.Lock()
+	old, ok := s.subscribersMap[key]
+	if !ok {
+		s.mu.Unlock()
+		s.AddListenerWith(listener, append(opts[:len(opts):len(opts)], key)...)
+		return false
+	}
+
+	sub := &keyedListener[T]{
+		key:      old.key,
+		listener: ignoreResult(listener),
+		priority: old.priority,
+		timeout:  old.timeout,
+		filter:   old.filter,
+		tags:     old.tags,
+		once:     old.once,
+		bound:    old.bound,
+		queue:    old.queue,
+	}
+	sub.fired.Store(old.fired.Load())
+	sub.of(listener)
+
+	subscribers := make([]*keyedListener[T], len(s.subscribers))
+	for i, candidate := range s.subscribers {
+		if candidate == old {
+			candidate = sub
+		}
+		subscribers[i] = candidate
+	}
+	s.setSubscribers(subscribers)
+	s.unregister(old)
+	s.subscribersMap[key] = sub
+	s.mu.Unlock()
+
+	if sub.bound != nil {
+		s.bind(sub)
+	}
+
+	return true
+}
+
+// AddListenerOnce adds a listener to the signal that is invoked at most once.
+// The listener is removed from the signal right before its first invocation,
+// so it never runs twice even when the signal is emitted concurrently. It
+// accepts the same options as AddListenerWith and returns the number of
+// subscribers after the listener was added, or -1 if the listener with the
+// same key was already added to the signal.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	signal.AddListenerOnce(func(ctx context.Context, payload int) {
+//		// Called for the first emission only
+//		// ...
+//	})
+func (s *BaseSignal[T]) AddListenerOnce(listener SignalListener[T], opts ...ListenerOption) int {
+	sub := newSubscriber(ignoreResult(listener), newListenerConfig(opts)).of(listener)
+	sub.once = true
+
+	return s.add(sub)
+}
+
+// AddListenerFiltered adds a listener that is only invoked for the payloads
+// accepted by the filter. The filter is evaluated before the listener is
+// invoked, and, for an asynchronous signal, before a goroutine is started for
+// it. It accepts the same options and has the same return values as
+// AddListener; the listener counts toward Len and can be removed by key like
+// any other listener.
+//
+// Example:
+//
+//	signal := signals.New[Event]()
+//	signal.AddListenerFiltered(func(ctx context.Context, payload Event) {
+//		// Only called for the "created" events
+//	}, func(payload Event) bool {
+//		return payload.Type == "created"
+//	})
+func (s *BaseSignal[T]) AddListenerFiltered(listener SignalListener[T], filter func(T) bool, opts ...ListenerOption) int {
+	sub := newSubscriber(ignoreResult(listener), newListenerConfig(opts)).of(listener)
+	sub.filter = filter
+
+	return s.add(sub)
+}
+
+// ignoreResult adapts a SignalListener to a ResultListener that always
+// succeeds.
+func ignoreResult[T any](listener SignalListener[T]) ResultListener[T] {
+	return func(ctx context.Context, payload T) error {
+		listener(ctx, payload)
+		return nil
+	}
+}
+
+// newSubscriber creates a subscriber for the listener configured by cfg. A
+// listener added without a key is given a generated one, so it can still be
+// told apart by an Observer.
+func newSubscriber[T any](listener ResultListener[T], cfg listenerConfig) *keyedListener[T] {
+	if !cfg.hasKey {
+		cfg.key = generateKey()
+	}
+
+	return &keyedListener[T]{
+		key:       cfg.key,
+		generated: !cfg.hasKey,
+		listener:  listener,
+		priority:  cfg.priority,
+		timeout:   cfg.timeout,
+		tags:      cfg.tags,
+	}
+}
+
+// of records the function the subscriber was created for, which WithDedup
+// compares, and returns the subscriber.
+func (sub *keyedListener[T]) of(fn any) *keyedListener[T] {
+	sub.fn = reflect.ValueOf(fn).Pointer()
+
+	return sub
+}
+
+// duplicate reports whether a subscriber of the same function was already
+// added to a signal created with WithDedup. It must be called with the lock
+// held.
+func (s *BaseSignal[T]) duplicate(sub *keyedListener[T]) bool {
+	if !s.config.dedup || sub.fn == 0 {
+		return false
+	}
+
+	for _, other := range s.subscribers {
+		if other.fn == sub.fn {
+			return true
+		}
+	}
+
+	return false
+}
+
+// add registers the subscriber. It returns the number of subscribers after
+// the subscriber was added, -1 if a subscriber with the same key was already
+// added, or ListenerLimitReached if the signal has the maximum number of
+// listeners.
+//
+// If the signal replays its last value, the subscriber is invoked with it
+// before add returns.
+func (s *BaseSignal[T]) add(sub *keyedListener[T]) int {
+	return s.addWith(sub, nil)
+}
+
+// addWith registers the subscriber like add. If catchUp is not nil, it is
+// called with the lock held, right after the subscriber was registered, with
+// the values of the history, or the value to replay for a signal without
+// history; the subscriber is then not invoked with the replayed value. Since
+// the emissions record their values and take their subscribers under the
+// same lock, each value is either passed to catchUp or delivered to the
+// subscriber, never both.
+func (s *BaseSignal[T]) addWith(sub *keyedListener[T], catchUp func(values []T)) int {
+	if s.config.ordered {
+		sub.queue = &serialQueue{}
+	}
+
+	s.mu.Lock()
+	for sub.generated && s.subscribersMap[sub.key] != nil {
+		// The key was chosen by hand for another listener.
+		sub.key = generateKey()
+	}
+	if _, ok := s.subscribersMap[sub.key]; ok || s.duplicate(sub) {
+		s.mu.Unlock()
+		return -1
+	}
+	if s.subscribersMap == nil {
+		s.subscribersMap = make(map[SignalType]*keyedListener[T])
+	}
+	if s.config.maxListeners > 0 && len(s.subscribers) >= s.config.maxListeners {
+		s.mu.Unlock()
+		return ListenerLimitReached
+	}
+	if sub.handler != nil {
+		if _, ok := s.handlers[sub.handler]; ok {
+			s.mu.Unlock()
+			return -1
+		}
+		if s.handlers == nil {
+			s.handlers = make(map[Handler[T]]*keyedListener[T])
+		}
+		s.handlers[sub.handler] = sub
+	}
+	s.subscribersMap[sub.key] = sub
+
+	s.insert(sub)
+	count := len(s.subscribers)
+	last, replay := s.last, s.config.replay && s.emitted
+	if catchUp != nil {
+		values := s.history.values()
+		if len(values) == 0 && replay {
+			values = append(values, last)
+		}
+		catchUp(values)
+		replay = false
+	}
+	s.mu.Unlock()
+
+	if replay {
+		_ = s.call(context.Background(), sub, last)
+	}
+	if a, ok := s.impl.(adder); ok {
+		a.added()
+	}
+
+	return count
+}
+
+// insert places the subscriber after all subscribers having the same or a
+// higher priority, which keeps ties in insertion order, or before the ones
+// having the same priority for a signal created with WithLIFO. The
+// subscribers slice is never modified in place, so a slice obtained from
+// listeners stays valid while the signal is being modified. It must be called
+// with the lock held.
+func (s *BaseSignal[T]) insert(sub *keyedListener[T]) {
+	i := len(s.subscribers)
+	for i > 0 && (s.subscribers[i-1].priority < sub.priority ||
+		s.config.lifo && s.subscribers[i-1].priority == sub.priority) {
+		i--
+	}
+
+	subscribers := make([]*keyedListener[T], 0, len(s.subscribers)+1)
+	subscribers = append(subscribers, s.subscribers[:i]...)
+	subscribers = append(subscribers, sub)
+	s.setSubscribers(append(subscribers, s.subscribers[i:]...))
+}
+
+// setSubscribers replaces the subscribers and updates their count. It must
+// be called with the lock held.
+func (s *BaseSignal[T]) setSubscribers(subscribers []*keyedListener[T]) {
+	s.subscribers = subscribers
+	s.count.Store(int64(len(subscribers)))
+}
+
+// delete removes the subscriber from the subscribers slice without modifying
+// the slice in place. It returns false if the subscriber was not found. It
+// must be called with the lock held.
+func (s *BaseSignal[T]) delete(sub *keyedListener[T]) bool {
+	for i, candidate := range s.subscribers {
+		if candidate == sub {
+			subscribers := make([]*keyedListener[T], 0, len(s.subscribers)-1)
+			subscribers = append(subscribers, s.subscribers[:i]...)
+			s.setSubscribers(append(subscribers, s.subscribers[i+1:]...))
+			s.unregister(sub)
+			return true
+		}
+	}
+
+	return false
+}
+
+// unregister removes the subscriber from the lookup maps of the signal, and
+// stops the removal of a listener added with AddListenerCtx. It must be called
+// with the lock held.
+func (s *BaseSignal[T]) unregister(sub *keyedListener[T]) {
+	delete(s.subscribersMap, sub.key)
+	if sub.handler != nil {
+		delete(s.handlers, sub.handler)
+	}
+	if sub.unbind != nil {
+		sub.unbind()
+	}
+}
+
+// listeners returns the current subscribers. The returned slice must not be
+// modified.
+func (s *BaseSignal[T]) listeners() []*keyedListener[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.subscribers
+}
+
+// emit runs an emission of the payload using the given dispatcher, or queues
+// it if the signal has a buffer or is paused. It returns ErrSignalClosed if the
+// signal was closed.
+func (s *BaseSignal[T]) emit(ctx context.Context, d dispatcher[T], payload T) error {
+	return s.emitWith(ctx, d, emission[T]{payload: payload})
+}
+
+// admit checks the value of an emission with the validator set with
+// WithValidator, then with the gate set with WithGate. It returns the error
+// of the validator, or ErrGated if the gate rejected the value.
+func (s *BaseSignal[T]) admit(ctx context.Context, v T) error {
+	if s.validate != nil {
+		if err := s.validate(v); err != nil {
+			return err
+		}
+	}
+
+	if s.gate != nil && !s.gate(ctx, v) {
+		return ErrGated
+	}
+
+	return nil
+}
+
+// emitWith implements emit for any emission.
+func (s *BaseSignal[T]) emitWith(ctx context.Context, d dispatcher[T], e emission[T]) error {
+	if d == nil {
+		return ErrNotImplemented
+	}
+
+	if !s.activity.begin() {
+		return ErrSignalClosed
+	}
+	defer s.activity.end()
+
+	ctx, cancel := s.bound(ctx)
+	defer cancel()
+
+	if e.factory != nil {
+		e.payload = e.factory()
+	}
+
+	if err := s.admit(ctx, e.payload); err != nil {
+		return err
+	}
+
+	if s.coalesce != nil && s.coalesce.repeat(e.payload) {
+		return nil
+	}
+
+	if !s.allow() {
+		return ErrRateLimited
+	}
+
+	if held, err := s.held(ctx, e); held {
+		return err
+	}
+
+	return s.send(ctx, d, e)
+}
+
+// bound derives the context of an emission from ctx with the deadline set with
+// WithDefaultTimeout, if any. The earlier of the two deadlines applies.
+func (s *BaseSignal[T]) bound(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.config.defaultTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, s.config.defaultTimeout)
+}
+
+// allow takes a token from the rate limiter of the signal, if any, and
+// returns false if the emission exceeds the limit.
+func (s *BaseSignal[T]) allow() bool {
+	return s.limiter == nil || s.limiter.allow()
+}
+
+// send queues the emission if the signal has a buffer, or delivers it.
+func (s *BaseSignal[T]) send(ctx context.Context, d dispatcher[T], e emission[T]) error {
+	if s.buffer != nil {
+		err := s.buffer.push(ctx, e)
+		if err == ErrDropped {
+			s.stats.dropped.Add(1)
+		}
+		return err
+	}
+
+	return s.deliver(ctx, d, e)
+}
+
+// deliver invokes the subscribers for the emission using the dispatcher.
+func (s *BaseSignal[T]) deliver(ctx context.Context, d dispatcher[T], e emission[T]) error {
+	e, subscribers, ok := s.prepare(e)
+	if !ok {
+		return nil
+	}
+	if e.progress != nil {
+		keys := make([]SignalType, 0, len(subscribers))
+		for _, sub := range subscribers {
+			if e.accepts(sub) {
+				keys = append(keys, sub.key)
+			}
+		}
+		e.progress.start(keys)
+	}
+	if e.acks != nil {
+		e.acks.start()
+	}
+	ctx = s.dispatched(ctx, e, len(subscribers))
+
+	unheard := s.unheard(e, subscribers)
+	if err := d.dispatch(ctx, subscribers, e); err != nil || !unheard {
+		return err
+	}
+
+	return ErrNoListeners
+}
+
+// dispatched counts an emission dispatched to n subscribers, and reports it
+// to the observer of the signal, if any. It returns the context passed to the
+// subscribers, which carries the sequence number of the emission and, for the
+// delta listeners, the previous value.
+func (s *BaseSignal[T]) dispatched(ctx context.Context, e emission[T], n int) context.Context {
+	s.stats.emits.Add(1)
+	if s.config.observer != nil {
+		s.config.observer.OnEmit(n)
+	}
+	if e.delta != nil {
+		ctx = context.WithValue(ctx, deltaKey{}, *e.delta)
+	}
+
+	return withSeq(ctx, s.seq.Add(1))
+}
+
+// unheard reports whether no subscriber accepts the emission of a signal
+// created with WithErrorOnNoListeners.
+func (s *BaseSignal[T]) unheard(e emission[T], subscribers []*keyedListener[T]) bool {
+	if !s.config.errorOnNoListeners {
+		return false
+	}
+
+	for _, sub := range subscribers {
+		if e.accepts(sub) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// init completes the construction of a signal created with the options of
+// cfg and dispatching its emissions with d.
+func (s *BaseSignal[T]) init(cfg signalConfig, d dispatcher[T]) {
+	s.config = cfg
+	s.impl = d
+	if equal, ok := cfg.equal.(func(a, b T) bool); ok {
+		s.distinct.equal = equal
+	}
+	s.gate, _ = cfg.gate.(func(ctx context.Context, v T) bool)
+	s.validate, _ = cfg.validator.(func(v T) error)
+	if coalesce, ok := cfg.coalesce.(func() coalescing[T]); ok {
+		s.coalesce = coalesce()
+	}
+	if cfg.rate > 0 {
+		s.limiter = newLimiter(cfg.rate, cfg.burst)
+	}
+	if cfg.bufferSize != 0 {
+		s.buffer = newBuffer(cfg.bufferSize, cfg.overflow, &s.activity, func(ctx context.Context, e emission[T]) {
+			_ = s.deliver(ctx, d, e)
+		})
+	}
+	s.Reset()
+}
+
+// QueueDepth returns the number of values waiting to be delivered by a signal
+// created with WithBuffer. It returns 0 for a signal without a buffer.
+func (s *BaseSignal[T]) QueueDepth() int {
+	if s.buffer == nil {
+		return 0
+	}
+
+	return s.buffer.depth()
+}
+
+// Drain removes the values waiting to be delivered and returns them instead,
+// in the order they would have been delivered, for instance to persist them
+// on shutdown and emit them again on restart. The values are the ones queued
+// by a signal created with WithBuffer, by descending priority (see
+// EmitWithPriority) and then in the order they were emitted, followed by the
+// ones kept by a paused signal with the PauseReplay policy. The drained values
+// are never delivered.
+//
+// A value the buffer already handed to the listeners is not drained: it is
+// being delivered, and Wait still waits for it. Since the drained values no
+// longer count as emissions in progress, calling Drain after Close lets Wait
+// return as soon as the values being delivered are done, instead of waiting
+// for the whole buffer to be delivered. The values emitted after Drain are
+// queued as usual.
+//
+// Example:
+//
+//	signal.Close()
+//	pending := signal.Drain()
+//	signal.Wait(shutdownCtx)
+//	store.SaveForReplay(pending)
+func (s *BaseSignal[T]) Drain() []T {
+	var values []T
+	if s.buffer != nil {
+		values = s.buffer.drain()
+	}
+
+	return append(values, s.pause.drain()...)
+}
+
+// tryDeliver invokes the subscribers for the emission like deliver if the
+// dispatcher can start it without waiting. It returns false if it cannot, in
+// which case the payload is not recorded for replay.
+func (s *BaseSignal[T]) tryDeliver(ctx context.Context, d dispatcher[T], e emission[T]) (bool, error) {
+	var run func(context.Context) error
+	reserve := func(subscribers []*keyedListener[T]) bool {
+		run = d.reserve(subscribers, e)
+		return run != nil
+	}
+
+	var subscribers []*keyedListener[T]
+	if s.records(e) || s.dedups(e) {
+		s.mu.Lock()
+		previous := s.distinct
+		if s.dedups(e) {
+			var ok bool
+			if e, ok = s.distinct.filter(e); !ok {
+				s.mu.Unlock()
+				return true, nil
+			}
+		}
+		subscribers = s.subscribers
+		if reserve(subscribers) {
+			if s.records(e) {
+				e = s.record(e)
+			}
+		} else {
+			s.distinct = previous
+		}
+		s.mu.Unlock()
+	} else {
+		subscribers = s.listeners()
+		reserve(subscribers)
+	}
+
+	if run == nil {
+		return false, nil
+	}
+
+	ctx = s.dispatched(ctx, e, len(subscribers))
+
+	unheard := s.unheard(e, subscribers)
+	if err := run(ctx); err != nil || !unheard {
+		return true, err
+	}
+
+	return true, ErrNoListeners
+}
+
+// prepare is called at the start of an emission. It removes the repeated
+// values of a signal created with WithEquality, records the emission as
+// described by record, and returns the emission and the subscribers to invoke.
+// It returns false if no value remains to deliver.
+func (s *BaseSignal[T]) prepare(e emission[T]) (emission[T], []*keyedListener[T], bool) {
+	if !s.records(e) && !s.dedups(e) {
+		return e, s.listeners(), true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dedups(e) {
+		var ok bool
+		if e, ok = s.distinct.filter(e); !ok {
+			return e, nil, false
+		}
+	}
+	if s.records(e) {
+		e = s.record(e)
+	}
+
+	return e, s.subscribers, true
+}
+
+// dedups reports whether the repeated values of the emission must be skipped,
+// which is the case if the signal was created with WithEquality, unless the
+// emission targets tagged listeners only.
+func (s *BaseSignal[T]) dedups(e emission[T]) bool {
+	return s.distinct.equal != nil && !e.tagged
+}
+
+// records reports whether the emission must be recorded, which is the case if
+// the signal replays its last value or keeps a history, unless the emission
+// targets tagged listeners only.
+func (s *BaseSignal[T]) records(e emission[T]) bool {
+	return (s.config.replay || s.config.history > 0 || s.deltas.Load()) && !e.tagged
+}
+
+// record stores the payload, or the last value of a batch, as the value to
+// replay and as the previous value of the next emission, and adds the values
+// to the history. It returns the emission along with the previous value for
+// the delta listeners. It must be called with the lock held.
+func (s *BaseSignal[T]) record(e emission[T]) emission[T] {
+	if s.config.replay && !e.swapped {
+		s.last, s.emitted = e.payload, true
+	}
+
+	if s.deltas.Load() {
+		e.delta = &delta[T]{prev: s.previous, ok: s.hasPrevious}
+		s.previous, s.hasPrevious = e.payload, true
+	}
+
+	if e.values == nil {
+		s.history.add(e.payload)
+	}
+	for _, v := range e.values {
+		s.history.add(v)
+	}
+
+	return e
+}
+
+// queued returns the time an invocation starts waiting to run, or the zero
+// time if the observer of the signal does not measure it.
+func (s *BaseSignal[T]) queued() time.Time {
+	if s.config.queueObserver == nil {
+		return time.Time{}
+	}
+
+	return time.Now()
+}
+
+// started reports to the observer the time waited by an invocation queued at
+// the given time.
+func (s *BaseSignal[T]) started(sub *keyedListener[T], queued time.Time) {
+	if !queued.IsZero() {
+		s.config.queueObserver.OnListenerQueued(sub.key, time.Since(queued))
+	}
+}
+
+// tagged reports whether the subscriber has at least one of the tags.
+func (sub *keyedListener[T]) tagged(tags []string) bool {
+	for _, tag := range tags {
+		for _, own := range sub.tags {
+			if own == tag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// skipped reports to the callback set with WithOutcomeCallback, if any, that
+// the subscriber was not invoked for an emission.
+func (s *BaseSignal[T]) skipped(sub *keyedListener[T]) {
+	if s.config.onOutcome != nil {
+		s.config.onOutcome(sub.key, Outcome{Status: OutcomeSkipped})
+	}
+}
+
+// breakerChanged notifies the observer of the signal, if it implements
+// BreakerObserver, that the circuit breaker of a listener changed state.
+func (s *BaseSignal[T]) breakerChanged(key SignalType, state BreakerState) {
+	if s.config.breakerObserver != nil {
+		s.config.breakerObserver.OnBreakerStateChange(key, state)
+	}
+}
+
+// timed records that an invocation of the subscriber took d, and removes the
+// subscriber if it was slower than the threshold set with
+// WithSlowListenerEviction for too many consecutive invocations.
+func (s *BaseSignal[T]) timed(sub *keyedListener[T], d time.Duration) {
+	if d <= s.config.slowThreshold {
+		sub.slow.Store(0)
+		return
+	}
+	if int(sub.slow.Add(1)) < s.config.slowStrikes {
+		return
+	}
+
+	s.mu.Lock()
+	evicted := s.delete(sub)
+	s.mu.Unlock()
+	if evicted && s.config.evictionObserver != nil {
+		s.config.evictionObserver.OnListenerEvicted(sub.key)
+	}
+}
+
+// expired reports whether the context the subscriber is bound to is done.
+func (sub *keyedListener[T]) expired() bool {
+	return sub.bound != nil && sub.bound.Err() != nil
+}
+
+// accepts reports whether the subscriber must be invoked for the payload.
+func (sub *keyedListener[T]) accepts(payload T) bool {
+	return sub.filter == nil || sub.filter(payload)
+}
+
+// call invokes the listener of the subscriber and returns its error. A
+// listener added with AddListenerOnce is removed before it is invoked, and it
+// is skipped if it was already invoked by a concurrent emission. A listener
+// added with WithListenerTimeout receives a context bounded by its timeout.
+// The invocation is reported to the observer of the signal, if any.
+func (s *BaseSignal[T]) call(ctx context.Context, sub *keyedListener[T], payload T) error {
+	return s.callWith(ctx, sub, payload, nil)
+}
+
+// callBatch invokes the batch listener of the subscriber with the values, like
+// call.
+func (s *BaseSignal[T]) callBatch(ctx context.Context, sub *keyedListener[T], values []T) error {
+	return s.callWith(ctx, sub, *new(T), values)
+}
+
+// callWith implements call and callBatch. The batch listener of the
+// subscriber is invoked with the values if they are not nil, otherwise its
+// listener is invoked with the payload.
+func (s *BaseSignal[T]) callWith(ctx context.Context, sub *keyedListener[T], payload T, values []T) (err error) {
+	if sub.expired() {
+		s.mu.Lock()
+		s.delete(sub)
+		s.mu.Unlock()
+		s.skipped(sub)
+
+		return nil
+	}
+
+	if sub.once {
+		if !sub.fired.CompareAndSwap(false, true) {
+			s.skipped(sub)
+			return nil
+		}
+
+		s.mu.Lock()
+		s.delete(sub)
+		s.mu.Unlock()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	token, ok := sub.running.begin(cancel)
+	if !ok {
+		s.skipped(sub)
+		return nil
+	}
+	defer sub.running.end(token)
+
+	if s.config.breakerFailures > 0 && !sub.once {
+		ok, changed := sub.breaker.allow(time.Now())
+		if changed {
+			s.breakerChanged(sub.key, BreakerHalfOpen)
+		}
+		if !ok {
+			s.skipped(sub)
+			return nil
+		}
+		defer func() {
+			state, changed := sub.breaker.record(err, s.config.breakerFailures, s.config.breakerCooldown, time.Now())
+			if changed {
+				s.breakerChanged(sub.key, state)
+			}
+		}()
+	}
+
+	s.stats.inFlight.Add(1)
+	defer func() {
+		s.stats.inFlight.Add(-1)
+		s.stats.finished(err)
+	}()
+
+	if s.config.slowThreshold > 0 && !sub.once {
+		start := time.Now()
+		defer func() {
+			s.timed(sub, time.Since(start))
+		}()
+	}
+
+	if decorate := s.config.decorator; decorate != nil {
+		if decorated := decorate(ctx, sub.key); decorated != nil {
+			ctx = decorated
+		}
+	}
+
+	if sub.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sub.timeout)
+		defer cancel()
+	}
+
+	if fn := s.config.onOutcome; fn != nil {
+		defer func() {
+			fn(sub.key, outcomeOf(err))
+		}()
+	}
+
+	if obs := s.config.observer; obs != nil {
+		obs.OnListenerStart(sub.key)
+		start := time.Now()
+		defer func() {
+			obs.OnListenerEnd(sub.key, time.Since(start))
+			if err != nil && s.config.errorObserver != nil {
+				s.config.errorObserver.OnListenerError(sub.key, err)
+			}
+		}()
+	}
+
+	if values != nil {
+		return s.invokeBatch(ctx, sub, values)
+	}
+
+	return s.retry(ctx, sub, payload)
+}
+
+// retry invokes the listener of the subscriber, and invokes it again while it
+// fails, as configured by WithRetry. It returns the error of the last
+// invocation.
+func (s *BaseSignal[T]) retry(ctx context.Context, sub *keyedListener[T], payload T) error {
+	err := s.invoke(ctx, sub, payload)
+	for attempt := 1; err != nil && attempt <= s.config.retries; attempt++ {
+		if _, ok := err.(*PanicError); ok {
+			return err
+		}
+
+		if s.config.backoff != nil {
+			timer := time.NewTimer(s.config.backoff(attempt))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			}
+		} else if ctx.Err() != nil {
+			return err
+		}
+
+		err = s.invoke(ctx, sub, payload)
+	}
+
+	return err
+}
+
+// invoke runs the listener of the subscriber wrapped by the middlewares of the
+// signal. A panic of the listener or of a middleware is recovered and returned
+// as a *PanicError.
+func (s *BaseSignal[T]) invoke(ctx context.Context, sub *keyedListener[T], payload T) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = s.config.panicked(sub.key, recovered)
+		}
+	}()
+
+	s.mu.RLock()
+	middlewares := s.middlewares
+	s.mu.RUnlock()
+	if len(middlewares) == 0 {
+		return sub.listener(ctx, payload)
+	}
+
+	handler := SignalListener[T](func(ctx context.Context, payload T) {
+		err = sub.listener(ctx, payload)
+	})
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	handler(ctx, payload)
+
+	return err
+}
+
+// RemoveListener removes a listener from the signal. It returns the number
+// of subscribers after the listener was removed. It returns -1 if the
+// listener was not found.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	signal.AddListener(func(ctx context.Context, payload int) {
+//		// Listener implementation
+//		// ...
+//	}, "key1")
+//	count := signal.RemoveListener("key1")
+//	fmt.Println("Number of subscribers after removing listener:", count)
+func (s *BaseSignal[T]) RemoveListener(key SignalType) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub, ok := s.subscribersMap[key]; ok {
+		s.delete(sub)
+		return len(s.subscribers)
+	}
+
+	return -1
 }
 
-// BaseSignal provides the base implementation of the Signal interface.
-// It is intended to be used as an abstract base for underlying signal mechanisms.
+// RemoveListenerAndWait removes the listener with the given key like
+// RemoveListener, and then blocks until the invocations of that listener in
+// progress, including the ones running in the goroutines of an asynchronous
+// signal, have returned, so the resources the listener uses can be released
+// safely. Unlike with RemoveListener, the emissions in progress that did not
+// invoke the listener yet skip it.
+//
+// It returns the number of subscribers after the listener was removed, or -1
+// if no listener has the key, and the context error if the context is done
+// before the invocations returned; the listener is removed in any case.
 //
 // Example:
 //
-//	type MyDerivedSignal[T any] struct {
-//		BaseSignal[T]
-//		// Additional fields or methods specific to MyDerivedSignal
+//	if _, err := signal.RemoveListenerAndWait(ctx, key); err != nil {
+//		log.Println("listener still running:", err)
 //	}
+//	conn.Close()
+func (s *BaseSignal[T]) RemoveListenerAndWait(ctx context.Context, key SignalType) (int, error) {
+	s.mu.Lock()
+	sub, ok := s.subscribersMap[key]
+	if !ok {
+		s.mu.Unlock()
+		return -1, nil
+	}
+	s.delete(sub)
+	count := len(s.subscribers)
+	s.mu.Unlock()
+
+	return count, sub.running.close(ctx)
+}
+
+// CancelListener cancels the context of the invocations in progress of the
+// listener with the given key, for instance to unblock a stuck listener of an
+// asynchronous signal, and returns how many were cancelled. Each invocation
+// receives its own context derived from the emission context, so the other
+// listeners and the later invocations of the listener are not affected, and
+// the listener is not removed.
 //
-//	func (s *MyDerivedSignal[T]) Emit(ctx context.Context, payload T) {
-//		// Custom implementation for emitting the signal
-//	}
-type BaseSignal[T any] struct {
-	mu             sync.RWMutex
-	subscribers    []keyedListener[T]
-	subscribersMap map[SignalType]SignalListener[T]
+// The cancellation is cooperative and best-effort: it only makes ctx.Done()
+// of the invocations fire, so a listener that does not watch its context
+// keeps running. A listener added with AddListenerOnce is removed before it
+// is invoked, so its invocation cannot be cancelled. It returns 0 if no
+// listener has the key or if the listener is not running.
+//
+// Example:
+//
+//	signal.AddListener(func(ctx context.Context, job Job) {
+//		select {
+//		case <-process(job):
+//		case <-ctx.Done(): // Cancelled by CancelListener
+//		}
+//	}, workerKey)
+//	// ...
+//	signal.CancelListener(workerKey)
+func (s *BaseSignal[T]) CancelListener(key SignalType) int {
+	s.mu.RLock()
+	sub, ok := s.subscribersMap[key]
+	s.mu.RUnlock()
+	if !ok {
+		return 0
+	}
+
+	return sub.running.cancel()
 }
 
-// AddListener adds a listener to the signal. The listener will be called
-// whenever the signal is emitted. It returns the number of subscribers after
-// the listener was added. It accepts an optional key that can be used to remove
-// the listener later or to check if the listener was already added. It returns
-// -1 if the listener with the same key was already added to the signal.
+// RemoveGroup removes all the listeners added with the given tag (see
+// WithTags), and returns how many were removed. A listener belonging to
+// several groups is removed as soon as one of its groups is removed. Like the
+// other removals, it is safe to call during an emission, which still invokes
+// the listeners it started with.
 //
 // Example:
 //
-//	signal := signals.New[int]()
-//	count := signal.AddListener(func(ctx context.Context, payload int) {
-//		// Listener implementation
-//		// ...
-//	}, "key1")
-//	fmt.Println("Number of subscribers after adding listener:", count)
-func (s *BaseSignal[T]) AddListener(listener SignalListener[T], key ...SignalType) int {
+//	signal.AddListener(audit, signals.WithTags("audit"))
+//	// ...
+//	removed := signal.RemoveGroup("audit")
+func (s *BaseSignal[T]) RemoveGroup(tag string) int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if len(key) > 0 {
-		if _, ok := s.subscribersMap[key[0]]; ok {
-			return -1
+
+	tags := []string{tag}
+	subscribers := make([]*keyedListener[T], 0, len(s.subscribers))
+	for _, sub := range s.subscribers {
+		if !sub.tagged(tags) {
+			subscribers = append(subscribers, sub)
+			continue
 		}
-		s.subscribersMap[key[0]] = listener
-		s.subscribers = append(s.subscribers, keyedListener[T]{
-			key:      key[0],
-			listener: listener,
-		})
-	} else {
-		s.subscribers = append(s.subscribers, keyedListener[T]{
-			listener: listener,
-		})
+		s.unregister(sub)
+	}
+
+	removed := len(s.subscribers) - len(subscribers)
+	if removed > 0 {
+		s.setSubscribers(subscribers)
 	}
 
-	return len(s.subscribers)
+	return removed
 }
 
-// RemoveListener removes a listener from the signal. It returns the number
-// of subscribers after the listener was removed. It returns -1 if the
-// listener was not found.
+// Use adds middlewares that wrap every invocation of the listeners of the
+// signal, including the listeners added before. The middlewares apply in the
+// order they were added: the first one is the outermost and runs first. They
+// are kept by Reset.
 //
 // Example:
 //
 //	signal := signals.New[int]()
-//	signal.AddListener(func(ctx context.Context, payload int) {
-//		// Listener implementation
-//		// ...
-//	}, "key1")
-//	count := signal.RemoveListener("key1")
-//	fmt.Println("Number of subscribers after removing listener:", count)
-func (s *BaseSignal[T]) RemoveListener(key SignalType) int {
+//	signal.Use(func(next signals.SignalListener[int]) signals.SignalListener[int] {
+//		return func(ctx context.Context, payload int) {
+//			start := time.Now()
+//			next(ctx, payload)
+//			log.Println("listener took", time.Since(start))
+//		}
+//	})
+func (s *BaseSignal[T]) Use(mws ...Middleware[T]) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if _, ok := s.subscribersMap[key]; ok {
-		delete(s.subscribersMap, key)
 
-		for i, sub := range s.subscribers {
-			if sub.key == key {
-				s.subscribers = append(s.subscribers[:i], s.subscribers[i+1:]...)
-				break
-			}
+	middlewares := make([]Middleware[T], 0, len(s.middlewares)+len(mws))
+	middlewares = append(middlewares, s.middlewares...)
+	s.middlewares = append(middlewares, mws...)
+}
+
+// HasListener reports whether a listener with the given key is subscribed to
+// the signal. It reflects the additions and removals as soon as they return.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	if !signal.HasListener(signals.SignalType(1)) {
+//		signal.AddListener(listener, signals.SignalType(1))
+//	}
+func (s *BaseSignal[T]) HasListener(key SignalType) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.subscribersMap[key]
+
+	return ok
+}
+
+// Keys returns the keys of the listeners subscribed to the signal, in the
+// order the listeners are invoked. Listeners added without a key, whose key
+// was generated by the package, are not included, whatever the sign of the
+// keys. The returned slice is a copy that the caller is free to modify.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	signal.AddListener(listener, signals.SignalType(1))
+//	signal.AddListener(listener)
+//	fmt.Println(signal.Keys()) // [1]
+func (s *BaseSignal[T]) Keys() []SignalType {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]SignalType, 0, len(s.subscribersMap))
+	for _, sub := range s.subscribers {
+		if !sub.generated {
+			keys = append(keys, sub.key)
 		}
-		return len(s.subscribers)
 	}
 
-	return -1
+	return keys
 }
 
 // Reset resets the signal by removing all subscribers from the signal,
 // effectively clearing the list of subscribers.
 // This can be used when you want to stop all listeners from receiving
-// further signals.
+// further signals. It also forgets the last value of a signal created with
+// WithReplay, and the last delivered value of a signal created with
+// WithEquality. Reset does not re-open a closed signal.
 //
 // Example:
 //
@@ -116,13 +1456,81 @@ func (s *BaseSignal[T]) RemoveListener(key SignalType) int {
 func (s *BaseSignal[T]) Reset() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.subscribers = s.subscribers[:0]
-	s.subscribersMap = make(map[SignalType]SignalListener[T])
+	s.reset()
+}
+
+// ResetAndCancel resets the signal like Reset, and also cancels the context of
+// the invocations of the removed listeners still in progress, like
+// CancelListener, then waits for them to return, or for the context to be
+// done, in which case it returns the context error. The emissions in progress
+// that did not invoke a removed listener yet skip it. Once it returned nil,
+// no removed listener runs anymore, which gives a clean slate, for instance to
+// reuse a pooled signal between test cases.
+//
+// Plain Reset leaves the work in progress alone: the invocations already
+// running go on, and the emissions in progress still invoke the removed
+// listeners they did not invoke yet. Like CancelListener, the cancellation is
+// cooperative: ResetAndCancel waits for the listeners ignoring their context
+// to return on their own. Pass a context that is already done to cancel
+// without waiting.
+//
+// Example:
+//
+//	if err := signal.ResetAndCancel(ctx); err != nil {
+//		log.Println("listeners still running:", err)
+//	}
+func (s *BaseSignal[T]) ResetAndCancel(ctx context.Context) error {
+	s.mu.Lock()
+	removed := s.reset()
+	s.mu.Unlock()
+
+	for _, sub := range removed {
+		sub.running.detach()
+		sub.running.cancel()
+	}
+	for _, sub := range removed {
+		if err := sub.running.wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reset implements Reset, and returns the removed subscribers. It must be
+// called with the lock held.
+func (s *BaseSignal[T]) reset() []*keyedListener[T] {
+	removed := s.subscribers
+	for _, sub := range s.subscribers {
+		if sub.unbind != nil {
+			sub.unbind()
+		}
+	}
+	s.setSubscribers(nil)
+	s.subscribersMap = make(map[SignalType]*keyedListener[T])
+	s.handlers = nil
+	s.closeListeners = nil
+
+	var zero T
+	s.last, s.emitted = zero, false
+	s.history = newHistory[T](s.config.history)
+	s.distinct.reset()
+	s.stats.reset()
+	s.seq.Store(0)
+	s.previous, s.hasPrevious = zero, false
+	if s.coalesce != nil {
+		s.coalesce.reset()
+	}
+
+	return removed
 }
 
 // Len returns the number of listeners subscribed to the signal.
 // This can be used to check how many listeners are currently waiting for a signal.
-// The returned value is of type int.
+// The returned value is of type int. Len reads a counter updated with the
+// listeners, without taking the lock of the signal, so it can be called
+// frequently, for instance for monitoring, without contending with the
+// emissions or the changes of the listeners.
 //
 // Example:
 //
@@ -133,15 +1541,13 @@ func (s *BaseSignal[T]) Reset() {
 //	})
 //	fmt.Println("Number of subscribers:", signal.Len())
 func (s *BaseSignal[T]) Len() int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	return len(s.subscribers)
+	return int(s.count.Load())
 }
 
 // IsEmpty checks if the signal has any subscribers.
 // It returns true if the signal has no subscribers, and false otherwise.
 // This can be used to check if there are any listeners before emitting a signal.
+// Like Len, it does not take the lock of the signal.
 //
 // Example:
 //
@@ -153,14 +1559,417 @@ func (s *BaseSignal[T]) Len() int {
 //	})
 //	fmt.Println("Is signal empty?", signal.IsEmpty()) // Should print false
 func (s *BaseSignal[T]) IsEmpty() bool {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	return s.count.Load() == 0
+}
+
+// Close marks the signal as closed. Once closed, Emit returns ErrSignalClosed
+// without invoking any listener, while the emissions already in progress
+// complete normally. Close is idempotent, and a closed signal cannot be
+// re-opened, not even by Reset. Use Wait to wait for the emissions in
+// progress to finish. Once they finished, the close listeners (see
+// AddCloseListener) are invoked in a new goroutine, and Wait also waits for
+// them.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	// ...
+//	signal.Close()
+//	if err := signal.Wait(shutdownCtx); err != nil {
+//		log.Println("listeners did not finish in time:", err)
+//	}
+func (s *BaseSignal[T]) Close() {
+	s.activity.closeThen(s.notifyClose)
+}
+
+// AddCloseListener adds a function invoked once when the signal is closed,
+// so a listener can flush or release its resources. The close listeners are
+// invoked after the emissions in progress at the time of Close finished, in
+// the order they were added, one after the other, with a background context,
+// and Wait returns once they all returned. A panic of a close listener is not
+// recovered. It returns false, without adding the function, if the signal is
+// already closed. Reset removes the close listeners, and Clone does not copy
+// them.
+//
+// Example:
+//
+//	signal.AddListener(writer.Write)
+//	signal.AddCloseListener(func(ctx context.Context) {
+//		writer.Flush()
+//	})
+//	// ...
+//	signal.Close()
+//	signal.Wait(shutdownCtx) // The writer was flushed
+func (s *BaseSignal[T]) AddCloseListener(listener func(ctx context.Context)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.activity.isClosed() {
+		return false
+	}
+
+	s.closeListeners = append(s.closeListeners, listener)
+
+	return true
+}
+
+// notifyClose invokes the close listeners.
+func (s *BaseSignal[T]) notifyClose() {
+	s.mu.Lock()
+	listeners := s.closeListeners
+	s.closeListeners = nil
+	s.mu.Unlock()
+
+	ctx := context.Background()
+	for _, listener := range listeners {
+		listener(ctx)
+	}
+}
+
+// Wait blocks until all the emissions in progress, including the listener
+// goroutines of an asynchronous signal, have finished, or until the context is
+// done, in which case it returns the context error. It is typically called
+// after Close to drain the signal during a graceful shutdown.
+func (s *BaseSignal[T]) Wait(ctx context.Context) error {
+	return s.activity.wait(ctx)
+}
+
+// EmitWithTimeout emits the payload like Emit, with a context derived from
+// ctx that is cancelled after the given timeout. The derived context is
+// always cancelled before EmitWithTimeout returns, once the listeners,
+// including the goroutines of an asynchronous signal, have returned.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	err := signal.EmitWithTimeout(context.Background(), 42, time.Second)
+func (s *BaseSignal[T]) EmitWithTimeout(ctx context.Context, payload T, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return s.emit(ctx, s.impl, payload)
+}
+
+// EmitWithPriority emits the payload like Emit, with the given priority in the
+// buffer of a signal created with WithBuffer: the buffered values are
+// delivered by descending priority, and in the order they were emitted among
+// the values of the same priority. The values emitted with Emit have the
+// priority 0. This lets urgent control values jump ahead of the bulk values
+// waiting in the buffer. A signal without a buffer delivers every value as
+// soon as it is emitted, so EmitWithPriority is the same as Emit.
+//
+// The priority is strict, without aging: a lower priority value waits as long
+// as higher priority values keep arriving, and is delayed indefinitely if
+// they arrive faster than the listeners handle them. Reserve the higher
+// priorities for values that are rare compared to the capacity of the
+// listeners. When the buffer is full, the DropOldest policy discards the
+// values of the lowest priority first.
+//
+// Example:
+//
+//	signal := signals.New[Command](signals.WithBuffer(1024, signals.Block))
+//	signal.Emit(ctx, bulkImport)
+//	signal.EmitWithPriority(ctx, cancelAll, 10) // Delivered before bulkImport if still buffered
+func (s *BaseSignal[T]) EmitWithPriority(ctx context.Context, payload T, priority int) error {
+	return s.emitWith(ctx, s.impl, emission[T]{payload: payload, priority: priority})
+}
+
+// MustEmit emits the payload like Emit, and panics with the error if Emit
+// returns one. It suits the call sites where an error can only come from a
+// programming mistake, so it does not need handling.
+//
+// The errors of Emit fall in two groups. ErrSignalClosed, ErrNotImplemented
+// and ErrNotSticky mean the signal is used the wrong way, and are always
+// programmer errors. The other ones, such as the errors and the *PanicError
+// of the listeners, the context errors, ErrDropped, ErrPaused, ErrGated and
+// ErrRateLimited, depend on the state of the program at run time: use Emit
+// and handle them if the signal can return them.
+//
+// Example:
+//
+//	ready := signals.NewSync[struct{}]()
+//	// ...
+//	ready.MustEmit(ctx, struct{}{}) // Panics if ready was closed
+func (s *BaseSignal[T]) MustEmit(ctx context.Context, payload T) {
+	if err := s.emit(ctx, s.impl, payload); err != nil {
+		panic(err)
+	}
+}
+
+// EmitFunc emits the payload returned by produce like Emit, but only calls
+// produce if the signal has at least one listener, so an expensive payload is
+// not built when nobody listens. It returns nil without calling produce if the
+// signal has no listener, and ErrSignalClosed if the signal was closed.
+//
+// The check and the emission are not atomic. A listener removed after the
+// check is not invoked, so produce may be called for an emission that invokes
+// no listener; a listener added after the check of a signal found empty misses
+// the payload, as it would if it was added after Emit returned.
+//
+// Example:
+//
+//	signal.EmitFunc(ctx, func() Snapshot {
+//		return store.Snapshot() // Skipped when nobody listens
+//	})
+func (s *BaseSignal[T]) EmitFunc(ctx context.Context, produce func() T) error {
+	if !s.activity.begin() {
+		return ErrSignalClosed
+	}
+	defer s.activity.end()
+
+	if s.IsEmpty() {
+		return s.noListeners()
+	}
+
+	return s.emit(ctx, s.impl, produce())
+}
+
+// noListeners returns the error of an emission that reaches no listener:
+// ErrNoListeners if the signal was created with WithErrorOnNoListeners, nil
+// otherwise.
+func (s *BaseSignal[T]) noListeners() error {
+	if s.config.errorOnNoListeners {
+		return ErrNoListeners
+	}
+
+	return nil
+}
+
+// EmitFactory emits a value built by factory like Emit, but each listener
+// receives its own value, built by its own call to factory right before it is
+// invoked. This is the safe way to pass a mutable value, such as a pointer to
+// a struct, to listeners that modify it while running concurrently on an
+// asynchronous signal. One more value is built at the start of the emission,
+// once the signal is known to be open: it is the one checked by the validator,
+// the gate and the filters of the listeners (see AddListenerFiltered), and the
+// one recorded by WithReplay and WithHistory. The factory may therefore be
+// called from multiple goroutines at once. It is not called at all if the
+// signal was closed, in which case EmitFactory returns ErrSignalClosed.
+//
+// A value without pointers, such as an int or a struct of such values, is
+// copied for each listener anyway, so Emit is already safe for it.
+//
+// Example:
+//
+//	signal.EmitFactory(ctx, func() *Order {
+//		return order.Clone()
+//	})
+func (s *BaseSignal[T]) EmitFactory(ctx context.Context, factory func() T) error {
+	return s.emitWith(ctx, s.impl, emission[T]{factory: factory})
+}
+
+// CompareAndEmit emits the new value like Emit if the value stored by a signal
+// created with WithReplay or NewSticky equals old, and returns true. The
+// comparison and the update of the stored value are atomic, so among
+// concurrent calls expecting the same old value, only one succeeds, which
+// makes CompareAndEmit suitable for coordinating state transitions. Before
+// the first emission, the stored value is the zero value, and so it is after
+// Reset.
+//
+// The values are compared with the function set with WithEquality, if any,
+// with == if T is comparable, and with reflect.DeepEqual otherwise. If the
+// comparison fails, CompareAndEmit returns false without emitting. It returns
+// ErrNotSticky if the signal does not store its last value.
+//
+// The new value is stored as soon as the comparison succeeds: it is replayed
+// to the listeners added afterwards even if the emission itself is dropped,
+// for instance because the signal is paused. The listeners of concurrent
+// successful calls may receive the values in a different order than the one
+// in which they were stored.
+//
+// Example:
+//
+//	state := signals.NewSticky[State]()
+//	if ok, err := state.CompareAndEmit(ctx, Idle, Running); err == nil && !ok {
+//		return errors.New("already running")
+//	}
+func (s *BaseSignal[T]) CompareAndEmit(ctx context.Context, old, new T) (bool, error) {
+	if s.impl == nil {
+		return false, ErrNotImplemented
+	}
+
+	if !s.config.replay {
+		return false, ErrNotSticky
+	}
 
-	return len(s.subscribers) == 0
+	if !s.activity.begin() {
+		return false, ErrSignalClosed
+	}
+	defer s.activity.end()
+
+	ctx, cancel := s.bound(ctx)
+	defer cancel()
+
+	if err := s.admit(ctx, new); err != nil {
+		return false, err
+	}
+
+	if !s.allow() {
+		return false, ErrRateLimited
+	}
+
+	s.mu.Lock()
+	if !s.equal(s.last, old) {
+		s.mu.Unlock()
+		return false, nil
+	}
+	s.last, s.emitted = new, true
+	s.mu.Unlock()
+
+	e := emission[T]{payload: new, swapped: true}
+	if held, err := s.held(ctx, e); held {
+		return true, err
+	}
+
+	return true, s.send(ctx, s.impl, e)
+}
+
+// equal compares two values with the function set with WithEquality, if any,
+// with == if T is comparable, and with reflect.DeepEqual otherwise.
+func (s *BaseSignal[T]) equal(a, b T) bool {
+	if s.distinct.equal != nil {
+		return s.distinct.equal(a, b)
+	}
+
+	if t := reflect.TypeOf(&a).Elem(); t.Comparable() && t.Kind() != reflect.Interface {
+		return any(a) == any(b)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// EmitN emits the payload like Emit, and also returns the number of listeners
+// invoked for it, which is 0 when nothing listens to the signal. The listeners
+// whose filter rejects the payload are not counted, and neither are the
+// listeners not invoked because an emission was stopped early, for instance
+// by the cancellation of the context of an asynchronous signal waiting for a
+// concurrency slot. The payload is counted as not delivered to any listener
+// if the signal buffers it (see WithBuffer), holds it while paused, or skips
+// it as a repeated value (see WithEquality), since no listener is invoked
+// before EmitN returns.
+//
+// Example:
+//
+//	if n, err := signal.EmitN(ctx, event); err == nil && n == 0 {
+//		log.Println("no listener for", event)
+//	}
+func (s *BaseSignal[T]) EmitN(ctx context.Context, payload T) (int, error) {
+	var invoked atomic.Int64
+	err := s.emitWith(ctx, s.impl, emission[T]{payload: payload, invoked: &invoked})
+
+	return int(invoked.Load()), err
+}
+
+// TryEmit emits the payload like Emit if it can be accepted without waiting,
+// and returns true. It returns false right away, without invoking any
+// listener, if the buffer of a signal created with WithBuffer is full,
+// whatever its overflow policy, or if an asynchronous signal would have to
+// wait for a concurrency slot (see WithMaxConcurrency). A synchronous signal
+// always accepts the payload. The error is the one Emit would have returned,
+// such as ErrSignalClosed, in which case the boolean is false too.
+//
+// Example:
+//
+//	signal := signals.New[Event](signals.WithBuffer(1024, signals.Block))
+//	if ok, err := signal.TryEmit(ctx, event); err == nil && !ok {
+//		dropped.Inc()
+//	}
+func (s *BaseSignal[T]) TryEmit(ctx context.Context, payload T) (bool, error) {
+	if s.impl == nil {
+		return false, ErrNotImplemented
+	}
+
+	if !s.activity.begin() {
+		return false, ErrSignalClosed
+	}
+	defer s.activity.end()
+
+	ctx, cancel := s.bound(ctx)
+	defer cancel()
+
+	if err := s.admit(ctx, payload); err != nil {
+		return false, err
+	}
+
+	if s.coalesce != nil && s.coalesce.repeat(payload) {
+		return true, nil
+	}
+
+	if !s.allow() {
+		return false, ErrRateLimited
+	}
+
+	e := emission[T]{payload: payload}
+	if held, err := s.held(ctx, e); held {
+		return err == nil, err
+	}
+
+	if s.buffer != nil {
+		return s.buffer.tryPush(ctx, e), nil
+	}
+
+	return s.tryDeliver(ctx, s.impl, e)
+}
+
+// EmitTagged emits the payload like Emit, but only invokes the listeners added
+// with WithTags having at least one of the given tags. The listeners without
+// tags are skipped, so EmitTagged without tags invokes no listener. A tagged
+// emission is not recorded by WithReplay, since it is not meant for every
+// listener.
+//
+// Example:
+//
+//	signal := signals.New[Event]()
+//	signal.AddListener(audit, signals.WithTags("audit"))
+//	signal.AddListener(count, signals.WithTags("metrics"))
+//	signal.EmitTagged(ctx, event, "audit") // Only invokes audit
+func (s *BaseSignal[T]) EmitTagged(ctx context.Context, payload T, tags ...string) error {
+	return s.emitWith(ctx, s.impl, emission[T]{payload: payload, tags: tags, tagged: true})
+}
+
+// EmitAndWait emits the payload like Emit and blocks until all the listeners,
+// including the goroutines of an asynchronous signal, have returned, or until
+// the context is done. The listeners that are still running then keep running
+// in the background; Wait can be used to wait for them. For a signal created
+// with WithBuffer, or for a paused signal, EmitAndWait returns once the value
+// was queued.
+//
+// If the context is done after the value reached the listeners, EmitAndWait
+// returns a *PartialDeliveryError, which matches ErrPartialDelivery and wraps
+// the context error, with the number of listeners that returned in time and
+// the keys of the others. If the context is done before, it returns the
+// context error.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	var partial *signals.PartialDeliveryError
+//	if err := signal.EmitAndWait(ctx, 42); errors.As(err, &partial) {
+//		log.Printf("listeners %v did not finish in time", partial.Pending)
+//	}
+func (s *BaseSignal[T]) EmitAndWait(ctx context.Context, payload T) error {
+	p := &progress{}
+	ctx, cancel := s.bound(ctx)
+	defer cancel()
+
+	// The emission is registered before the goroutine starts, so a Wait that
+	// follows a timed out EmitAndWait always waits for it.
+	s.activity.hold()
+	done := make(chan error, 1)
+	go func() {
+		defer s.activity.end()
+		done <- s.emitWith(ctx, s.impl, emission[T]{payload: payload, progress: p})
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return p.interrupted(ctx.Err())
+	}
 }
 
-// Emit is not implemented in BaseSignal and panics if called. It should be
-// implemented by a derived type.
+// Emit is not implemented in BaseSignal: it returns ErrNotImplemented without
+// invoking any listener. It should be implemented by a derived type.
 //
 // Example:
 //
@@ -172,9 +1981,6 @@ func (s *BaseSignal[T]) IsEmpty() bool {
 //	func (s *MyDerivedSignal[T]) Emit(ctx context.Context, payload T) {
 //		// Custom implementation for emitting the signal
 //	}
-
-var errNotImplemented = errors.New("the signal body should be implemented in derived type")
-
 func (s *BaseSignal[T]) Emit(ctx context.Context, payload T) error {
-	return errNotImplemented
+	return ErrNotImplemented
 }
@@ -0,0 +1,280 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// emission is what a dispatcher delivers to the subscribers: a single payload
+// emitted with Emit, or the values emitted together with EmitBatch.
+type emission[T any] struct {
+	payload T
+
+	// values holds the values of a batch, and is nil for a single payload.
+	values []T
+
+	// tagged marks an emission of EmitTagged, which only targets the
+	// subscribers having one of the tags.
+	tagged bool
+	tags   []string
+
+	// invoked, if set, counts the subscribers invoked for the emission, as
+	// reported by EmitN.
+	invoked *atomic.Int64
+
+	// factory, if set, builds the payload at the start of the emission and the
+	// value passed to each subscriber, as requested by EmitFactory. The payload
+	// is then only used by the filters and recorded for replay and history.
+	factory func() T
+
+	// swapped marks an emission of CompareAndEmit, whose payload was already
+	// stored as the value to replay.
+	swapped bool
+
+	// progress, if set, tracks the subscribers that have not returned yet,
+	// as reported by EmitAndWait.
+	progress *progress
+
+	// acks, if set, receives the ack of each subscriber, as reported by
+	// EmitWithAck.
+	acks *ackStream
+
+	// priority orders the emission in the buffer of a signal created with
+	// WithBuffer, as set with EmitWithPriority.
+	priority int
+
+	// delta, if set, holds the value emitted before the emission, passed to
+	// the listeners added with AddListenerDelta.
+	delta *delta[T]
+}
+
+// accepts reports whether the subscriber must be invoked for the emission,
+// that is whether it accepts the payload or at least one value of the batch,
+// and has one of the tags of a tagged emission.
+func (e emission[T]) accepts(sub *keyedListener[T]) bool {
+	if sub.expired() {
+		return false
+	}
+
+	if e.tagged && !sub.tagged(e.tags) {
+		return false
+	}
+
+	if e.values == nil {
+		return sub.accepts(e.payload)
+	}
+
+	for _, v := range e.values {
+		if sub.accepts(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deliverTo invokes the subscriber for the emission. A batch is passed at once
+// to a batch listener, and one value after the other to any other listener,
+// skipping the values its filter rejects.
+func (s *BaseSignal[T]) deliverTo(ctx context.Context, sub *keyedListener[T], e emission[T]) (err error) {
+	if e.invoked != nil && !sub.expired() && !(sub.once && sub.fired.Load()) {
+		e.invoked.Add(1)
+	}
+	if e.progress != nil {
+		defer e.progress.finish(sub.key)
+	}
+	if e.acks != nil {
+		defer func() {
+			e.acks.post(Ack{Key: sub.key, Err: err})
+		}()
+	}
+
+	if e.values == nil {
+		if e.factory != nil {
+			return s.call(ctx, sub, e.factory())
+		}
+
+		return s.call(ctx, sub, e.payload)
+	}
+
+	values := e.values
+	if sub.filter != nil {
+		values = make([]T, 0, len(e.values))
+		for _, v := range e.values {
+			if sub.filter(v) {
+				values = append(values, v)
+			}
+		}
+	}
+
+	if sub.batch != nil {
+		return s.callBatch(ctx, sub, values)
+	}
+
+	var errs []error
+	for _, v := range values {
+		if err := s.call(ctx, sub, v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// invokeBatch runs the batch listener of the subscriber. The middlewares of
+// the signal, which handle a single value, do not apply. A panic of the
+// listener is recovered and returned as a *PanicError.
+func (s *BaseSignal[T]) invokeBatch(ctx context.Context, sub *keyedListener[T], values []T) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = s.config.panicked(sub.key, recovered)
+		}
+	}()
+
+	sub.batch(ctx, values)
+
+	return nil
+}
+
+// AddBatchListener adds a listener that receives all the values emitted with
+// EmitBatch in a single invocation, in the order they were given. A value
+// emitted with Emit is passed as a batch of one value. It accepts the same
+// options and has the same return values as AddListenerWith. The middlewares
+// added with Use do not wrap the invocations of a batch.
+//
+// Example:
+//
+//	signal := signals.New[Event]()
+//	signal.AddBatchListener(func(ctx context.Context, events []Event) {
+//		store.InsertAll(ctx, events)
+//	})
+func (s *BaseSignal[T]) AddBatchListener(listener BatchListener[T], opts ...ListenerOption) int {
+	sub := newSubscriber(func(ctx context.Context, payload T) error {
+		listener(ctx, []T{payload})
+		return nil
+	}, newListenerConfig(opts))
+	sub.batch = listener
+	sub.of(listener)
+
+	return s.add(sub)
+}
+
+// EmitBatch emits several values in a single emission: the listeners are
+// looked up once, and each listener is invoked once for the whole batch. A
+// listener added with AddBatchListener receives the values in one call, and
+// any other listener is invoked with each value in turn. The values are always
+// received in the order of the slice, which the listeners must not modify.
+//
+// A synchronous signal invokes the listeners one after the other in priority
+// order, so a listener receives all the values before the next listener
+// receives any. An asynchronous signal starts a single goroutine per listener,
+// which receives the values in order while the other listeners run
+// concurrently. The errors are joined like Emit's. A signal with a buffer, or
+// a paused signal, queues the values one by one as if each was emitted with
+// Emit. EmitBatch does nothing if there are no values.
+//
+// Example:
+//
+//	signal := signals.NewSync[int]()
+//	err := signal.EmitBatch(ctx, []int{1, 2, 3})
+func (s *BaseSignal[T]) EmitBatch(ctx context.Context, values []T) error {
+	if s.impl == nil {
+		return ErrNotImplemented
+	}
+
+	if !s.activity.begin() {
+		return ErrSignalClosed
+	}
+	defer s.activity.end()
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	ctx, cancel := s.bound(ctx)
+	defer cancel()
+
+	if s.validate != nil {
+		for _, v := range values {
+			if err := s.validate(v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.gate != nil {
+		accepted := make([]T, 0, len(values))
+		for _, v := range values {
+			if s.gate(ctx, v) {
+				accepted = append(accepted, v)
+			}
+		}
+		if len(accepted) == 0 {
+			return ErrGated
+		}
+		values = accepted
+	}
+
+	if s.coalesce != nil {
+		kept := make([]T, 0, len(values))
+		for _, v := range values {
+			if !s.coalesce.repeat(v) {
+				kept = append(kept, v)
+			}
+		}
+		if len(kept) == 0 {
+			return nil
+		}
+		values = kept
+	}
+
+	if !s.allow() {
+		return ErrRateLimited
+	}
+
+	if s.buffer != nil || s.IsPaused() {
+		var errs []error
+		for _, v := range values {
+			e := emission[T]{payload: v}
+			if held, err := s.held(ctx, e); held {
+				errs = append(errs, err)
+				continue
+			}
+			errs = append(errs, s.send(ctx, s.impl, e))
+		}
+
+		return errors.Join(errs...)
+	}
+
+	e, subscribers, ok := s.prepare(emission[T]{payload: values[len(values)-1], values: values})
+	if !ok {
+		return nil
+	}
+	ctx = s.dispatched(ctx, e, len(subscribers))
+
+	unheard := s.unheard(e, subscribers)
+	if err := s.impl.dispatch(ctx, subscribers, e); err != nil || !unheard {
+		return err
+	}
+
+	return ErrNoListeners
+}
+
+// EmitMany emits the values in a single emission like EmitBatch, for the
+// values passed as separate arguments. The listeners see the values the same
+// way: a listener added with AddBatchListener receives them in one call, and
+// any other listener is invoked with each value in turn, as a separate
+// invocation. Each listener always receives the values in the order of the
+// arguments, with both a synchronous and an asynchronous signal; an
+// asynchronous signal runs the listeners concurrently with each other, but
+// invokes each of them with the values one after the other. EmitMany does
+// nothing if there are no values.
+//
+// Example:
+//
+//	signal := signals.New[Event]()
+//	err := signal.EmitMany(ctx, Created, Updated)
+func (s *BaseSignal[T]) EmitMany(ctx context.Context, values ...T) error {
+	return s.EmitBatch(ctx, values)
+}
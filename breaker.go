@@ -0,0 +1,63 @@
+package signals
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker is the circuit breaker of a listener of a signal created with
+// WithCircuitBreaker. Its zero value is a closed breaker.
+type breaker struct {
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	until    time.Time
+}
+
+// allow reports whether the listener may be invoked at the given time. Once
+// the cooldown of an open breaker elapsed, the breaker becomes half-open and
+// allows a single trial invocation; the others are refused until the trial
+// is recorded. changed reports whether the state of the breaker changed.
+func (b *breaker) allow(now time.Time) (ok, changed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerClosed:
+		return true, false
+	case BreakerOpen:
+		if now.Before(b.until) {
+			return false, false
+		}
+		b.state = BreakerHalfOpen
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// record records the result of an invocation allowed by allow, and returns
+// the state of the breaker and whether it changed. The breaker opens for the
+// cooldown after the given number of consecutive failures, or right away if
+// the trial invocation of a half-open breaker failed.
+func (b *breaker) record(err error, failures int, cooldown time.Duration, now time.Time) (state BreakerState, changed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	prev := b.state
+	switch {
+	case err == nil:
+		b.failures = 0
+		b.state = BreakerClosed
+	case b.state == BreakerHalfOpen:
+		b.state = BreakerOpen
+		b.until = now.Add(cooldown)
+	default:
+		b.failures++
+		if b.failures >= failures {
+			b.failures = 0
+			b.state = BreakerOpen
+			b.until = now.Add(cooldown)
+		}
+	}
+
+	return b.state, b.state != prev
+}
@@ -0,0 +1,239 @@
+package signals
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// OverflowPolicy decides what a signal created with WithBuffer does when a
+// value is emitted while its buffer is full.
+type OverflowPolicy int
+
+const (
+	// Block makes Emit wait until there is room in the buffer, or until its
+	// context is done, in which case Emit returns the context error.
+	Block OverflowPolicy = iota
+
+	// DropOldest discards the oldest value of the buffer to make room for the
+	// emitted value, among the values of the lowest priority when values were
+	// emitted with EmitWithPriority. Emit returns ErrDropped.
+	DropOldest
+
+	// DropNewest discards the emitted value. Emit returns ErrDropped.
+	DropNewest
+)
+
+// bufferedEmission is an emission waiting in the buffer of a signal.
+type bufferedEmission[T any] struct {
+	ctx   context.Context
+	value emission[T]
+
+	// seq orders the values of the same priority in the buffer of a signal.
+	seq uint64
+}
+
+// bufferHeap orders the values of a buffer by descending priority, then in
+// the order they were stored. It implements heap.Interface.
+type bufferHeap[T any] []bufferedEmission[T]
+
+func (h bufferHeap[T]) Len() int { return len(h) }
+
+func (h bufferHeap[T]) Less(i, j int) bool {
+	if h[i].value.priority != h[j].value.priority {
+		return h[i].value.priority > h[j].value.priority
+	}
+
+	return h[i].seq < h[j].seq
+}
+
+func (h bufferHeap[T]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *bufferHeap[T]) Push(x any) { *h = append(*h, x.(bufferedEmission[T])) }
+
+func (h *bufferHeap[T]) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = bufferedEmission[T]{}
+	*h = old[:len(old)-1]
+
+	return item
+}
+
+// buffer is the priority queue of a signal created with WithBuffer. Its
+// values are delivered one after the other by a goroutine that only lives
+// while the buffer is not empty.
+type buffer[T any] struct {
+	mu       sync.Mutex
+	items    bufferHeap[T]
+	capacity int
+	seq      uint64
+	policy   OverflowPolicy
+	running  bool
+
+	// popped is closed and replaced whenever room is made in the buffer, to
+	// wake up the emissions blocked by the Block policy.
+	popped chan struct{}
+
+	// activity is held by every value of the buffer, so Wait waits for the
+	// buffered values to be delivered.
+	activity *activity
+
+	// deliver invokes the listeners for a value taken from the buffer.
+	deliver func(ctx context.Context, e emission[T])
+}
+
+// newBuffer creates a buffer holding at least one value.
+func newBuffer[T any](capacity int, policy OverflowPolicy, a *activity, deliver func(context.Context, emission[T])) *buffer[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &buffer[T]{
+		items:    make(bufferHeap[T], 0, capacity),
+		capacity: capacity,
+		policy:   policy,
+		popped:   make(chan struct{}),
+		activity: a,
+		deliver:  deliver,
+	}
+}
+
+// push adds the value to the buffer according to the overflow policy. The
+// context is kept for its values only, since the value is delivered after
+// Emit returned.
+func (b *buffer[T]) push(ctx context.Context, e emission[T]) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var err error
+	for len(b.items) == b.capacity {
+		switch b.policy {
+		case DropNewest:
+			return ErrDropped
+		case DropOldest:
+			b.remove(b.oldest())
+			b.activity.end()
+			err = ErrDropped
+		default:
+			popped := b.popped
+			b.mu.Unlock()
+			select {
+			case <-popped:
+				b.mu.Lock()
+			case <-ctx.Done():
+				b.mu.Lock()
+				return ctx.Err()
+			}
+		}
+	}
+
+	b.store(ctx, e)
+
+	return err
+}
+
+// tryPush adds the value to the buffer if there is room, and returns whether
+// it did. It never blocks nor discards a value, whatever the overflow policy.
+func (b *buffer[T]) tryPush(ctx context.Context, e emission[T]) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.items) == b.capacity {
+		return false
+	}
+
+	b.store(ctx, e)
+
+	return true
+}
+
+// store adds the value to a buffer that is not full, and starts the delivery
+// goroutine if needed. It must be called with the lock held.
+func (b *buffer[T]) store(ctx context.Context, e emission[T]) {
+	b.activity.hold()
+	b.seq++
+	heap.Push(&b.items, bufferedEmission[T]{
+		ctx:   detach(ctx),
+		value: e,
+		seq:   b.seq,
+	})
+
+	if !b.running {
+		b.running = true
+		go b.run()
+	}
+}
+
+// oldest returns the index of the oldest value among the values of the lowest
+// priority, which DropOldest discards. It must be called with the lock held,
+// on a buffer that is not empty.
+func (b *buffer[T]) oldest() int {
+	i := 0
+	for j, item := range b.items {
+		lowest := b.items[i].value.priority
+		if item.value.priority < lowest || item.value.priority == lowest && item.seq < b.items[i].seq {
+			i = j
+		}
+	}
+
+	return i
+}
+
+// remove removes and returns the value at index i. It must be called with the
+// lock held.
+func (b *buffer[T]) remove(i int) bufferedEmission[T] {
+	item := heap.Remove(&b.items, i).(bufferedEmission[T])
+
+	close(b.popped)
+	b.popped = make(chan struct{})
+
+	return item
+}
+
+// pop removes and returns the value to deliver next: the one of the highest
+// priority, the oldest among them. It must be called with the lock held, on a
+// buffer that is not empty.
+func (b *buffer[T]) pop() bufferedEmission[T] {
+	return b.remove(0)
+}
+
+// depth returns the number of values waiting in the buffer.
+func (b *buffer[T]) depth() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.items)
+}
+
+// drain removes all the values of the buffer and returns their payloads, in
+// the order they would have been delivered.
+func (b *buffer[T]) drain() []T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	values := make([]T, 0, len(b.items))
+	for len(b.items) > 0 {
+		item := b.pop()
+		values = append(values, item.value.payload)
+		b.activity.end()
+	}
+
+	return values
+}
+
+// run delivers the values until the buffer is empty.
+func (b *buffer[T]) run() {
+	for {
+		b.mu.Lock()
+		if len(b.items) == 0 {
+			b.running = false
+			b.mu.Unlock()
+			return
+		}
+		item := b.pop()
+		b.mu.Unlock()
+
+		b.deliver(item.ctx, item.value)
+		b.activity.end()
+	}
+}
@@ -0,0 +1,114 @@
+package signals
+
+// Clone returns a new synchronous signal with the same options, listeners and
+// middlewares as the signal. The listeners keep their keys, priorities and
+// other options, and the functions are shared: only the registration table is
+// copied. The clone has its own lifecycle, so adding or removing listeners,
+// resetting, pausing or closing one signal does not affect the other. A value
+// stored by WithReplay and the history are copied too. The listeners added
+// with AddListenerCtx are not tied to their context in the clone.
+//
+// Example:
+//
+//	base := signals.NewSync[Request]()
+//	base.AddListener(logRequest)
+//
+//	scoped := base.Clone()
+//	scoped.AddListener(traceRequest) // base is not affected
+func (s *SyncSignal[T]) Clone() Signal[T] {
+	c := newSyncConfig[T](s.config)
+	s.copyTo(&c.BaseSignal)
+
+	return c
+}
+
+// Clone returns a new asynchronous signal with the same options, listeners
+// and middlewares as the signal. The listeners keep their keys, priorities and
+// other options, and the functions are shared: only the registration table is
+// copied. The clone has its own lifecycle, so adding or removing listeners,
+// resetting, pausing or closing one signal does not affect the other. A value
+// stored by WithReplay and the history are copied too. The listeners added
+// with AddListenerCtx are not tied to their context in the clone.
+func (s *AsyncSignal[T]) Clone() Signal[T] {
+	c := newAsyncConfig[T](s.config)
+	s.copyTo(&c.BaseSignal)
+
+	return c
+}
+
+// copyTo copies the subscribers, the middlewares, the replayed value, the
+// history, the last value delivered by a distinct signal and the previous
+// value of the delta listeners to the new signal c. The context a listener
+// added with AddListenerCtx is bound to is not copied, so the copy of such a
+// listener is never removed automatically.
+func (s *BaseSignal[T]) copyTo(c *BaseSignal[T]) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c.subscribers = make([]*keyedListener[T], 0, len(s.subscribers))
+	for _, sub := range s.subscribers {
+		dup := &keyedListener[T]{
+			key:       sub.key,
+			generated: sub.generated,
+			listener:  sub.listener,
+			priority:  sub.priority,
+			timeout:   sub.timeout,
+			filter:    sub.filter,
+			tags:      sub.tags,
+			batch:     sub.batch,
+			once:      sub.once,
+			handler:   sub.handler,
+			fn:        sub.fn,
+		}
+		if c.config.ordered {
+			dup.queue = &serialQueue{}
+		}
+		c.subscribers = append(c.subscribers, dup)
+		c.subscribersMap[dup.key] = dup
+		if dup.handler != nil {
+			if c.handlers == nil {
+				c.handlers = make(map[Handler[T]]*keyedListener[T])
+			}
+			c.handlers[dup.handler] = dup
+		}
+	}
+
+	c.count.Store(int64(len(c.subscribers)))
+
+	c.middlewares = append([]Middleware[T](nil), s.middlewares...)
+	c.last, c.emitted = s.last, s.emitted
+	c.history = s.history.clone()
+	c.distinct.last, c.distinct.set = s.distinct.last, s.distinct.set
+	c.previous, c.hasPrevious = s.previous, s.hasPrevious
+	c.deltas.Store(s.deltas.Load())
+}
+
+// Clone returns a new signal whose listeners return an error, with the same
+// options, listeners and middlewares as the signal, which invokes them
+// synchronously or concurrently like the signal. See SyncSignal.Clone.
+func (s *ResultSignal[T]) Clone() *ResultSignal[T] {
+	if _, ok := s.Signal.(*AsyncSignal[T]); ok {
+		c := newAsyncConfig[T](s.base.config)
+		s.base.copyTo(&c.BaseSignal)
+
+		return &ResultSignal[T]{Signal: c, base: &c.BaseSignal}
+	}
+
+	c := newSyncConfig[T](s.base.config)
+	s.base.copyTo(&c.BaseSignal)
+
+	return &ResultSignal[T]{Signal: c, base: &c.BaseSignal}
+}
+
+// Clone returns a debounced signal with the same delay wrapping a clone of the
+// wrapped signal. The pending payload, if any, is not copied.
+func (s *DebouncedSignal[T]) Clone() Signal[T] {
+	return Debounce(s.Signal.Clone(), s.delay)
+}
+
+// Clone returns a throttled signal with the same interval wrapping a clone of
+// the wrapped signal. The open interval and the trailing value, if any, are
+// not copied.
+func (s *ThrottledSignal[T]) Clone() Signal[T] {
+	return Throttle(s.Signal.Clone(), s.interval)
+}
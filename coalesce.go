@@ -0,0 +1,89 @@
+package signals
+
+import (
+	"sync"
+	"time"
+)
+
+// coalescing is the state of a signal created with WithCoalesce. It is an
+// interface because the option requires a comparable type, which the type
+// parameter of BaseSignal is not.
+type coalescing[T any] interface {
+	// repeat reports whether the value was already emitted within the
+	// window, and otherwise remembers it as emitted now.
+	repeat(v T) bool
+
+	// reset forgets the values emitted.
+	reset()
+}
+
+// coalescer implements coalescing for a comparable type.
+type coalescer[T comparable] struct {
+	window time.Duration
+
+	mu    sync.Mutex
+	fired map[T]time.Time
+	swept time.Time
+}
+
+// repeat implements coalescing. The values whose window elapsed are forgotten
+// at most once per window, so the map does not grow with the values seen in
+// the past.
+func (c *coalescer[T]) repeat(v T) bool {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.swept) >= c.window {
+		for value, fired := range c.fired {
+			if now.Sub(fired) >= c.window {
+				delete(c.fired, value)
+			}
+		}
+		c.swept = now
+	}
+
+	if fired, ok := c.fired[v]; ok && now.Sub(fired) < c.window {
+		return true
+	}
+	if c.fired == nil {
+		c.fired = make(map[T]time.Time)
+	}
+	c.fired[v] = now
+
+	return false
+}
+
+// reset implements coalescing.
+func (c *coalescer[T]) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fired = nil
+}
+
+// WithCoalesce makes the signal emit each value at most once per window: the
+// first emission of a value is delivered right away, and the emissions of an
+// equal value, compared with ==, within the window that follows are swallowed
+// and return nil without invoking any listener. This absorbs a thundering
+// herd of goroutines emitting the same value, such as a cache key to
+// invalidate. Unlike NewThrottled and NewDebounced, which limit the emissions
+// whatever their value, the window is tracked per value, so distinct values
+// are never delayed or swallowed. The window starts at the delivered emission
+// and is not extended by the swallowed ones.
+//
+// The check is made at the start of the emission, after WithValidator and
+// WithGate. It applies to Emit and the methods built on it, to TryEmit, and to
+// EmitBatch, which only keeps the values not swallowed; CompareAndEmit is not
+// coalesced. Reset forgets the values emitted. The option is ignored by the
+// signals whose type parameter is not T.
+//
+// Example:
+//
+//	invalidate := signals.New[string](signals.WithCoalesce[string](100 * time.Millisecond))
+func WithCoalesce[T comparable](window time.Duration) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.coalesce = func() coalescing[T] {
+			return &coalescer[T]{window: window}
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// CollectorListener is a listener that computes a value from the payload. The
+// values computed by all the listeners of a Collector are returned by its Emit
+// method.
+type CollectorListener[In, Out any] func(context.Context, In) Out
+
+// collectorEntry represents a listener of a Collector with its key.
+type collectorEntry[In, Out any] struct {
+	key      SignalType
+	hasKey   bool
+	priority int
+	listener CollectorListener[In, Out]
+}
+
+// Collector is a signal whose listeners return a value. Emit invokes all the
+// listeners and returns their values in the order of the listeners, which
+// makes the Collector a fan-out/fan-in primitive. Listeners are ordered by
+// descending priority like the listeners of the other signals.
+type Collector[In, Out any] struct {
+	mu          sync.RWMutex
+	subscribers []collectorEntry[In, Out]
+	async       bool
+	config      signalConfig
+}
+
+// NewCollector creates a new Collector that invokes its listeners
+// synchronously, one after the other.
+//
+// Example:
+//
+//	collector := signals.NewCollector[int, string]()
+//	collector.AddListener(func(ctx context.Context, payload int) string {
+//	    return strconv.Itoa(payload)
+//	})
+//	results, err := collector.Emit(context.Background(), 42)
+func NewCollector[In, Out any](opts ...SignalOption) *Collector[In, Out] {
+	return &Collector[In, Out]{config: newSignalConfig(opts)}
+}
+
+// NewAsyncCollector creates a new Collector that invokes its listeners
+// concurrently in separate goroutines. Emit waits for all the listeners to
+// finish and still returns their values in the order of the listeners.
+func NewAsyncCollector[In, Out any](opts ...SignalOption) *Collector[In, Out] {
+	return &Collector[In, Out]{async: true, config: newSignalConfig(opts)}
+}
+
+// AddListener adds a listener to the collector. It accepts the same options
+// and has the same return values as Signal.AddListenerWith.
+func (c *Collector[In, Out]) AddListener(listener CollectorListener[In, Out], opts ...ListenerOption) int {
+	cfg := newListenerConfig(opts)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cfg.hasKey {
+		for _, sub := range c.subscribers {
+			if sub.hasKey && sub.key == cfg.key {
+				return -1
+			}
+		}
+	} else {
+		cfg.key = generateKey()
+	}
+	if c.config.maxListeners > 0 && len(c.subscribers) >= c.config.maxListeners {
+		return ListenerLimitReached
+	}
+
+	i := len(c.subscribers)
+	for i > 0 && c.subscribers[i-1].priority < cfg.priority {
+		i--
+	}
+
+	subscribers := make([]collectorEntry[In, Out], 0, len(c.subscribers)+1)
+	subscribers = append(subscribers, c.subscribers[:i]...)
+	subscribers = append(subscribers, collectorEntry[In, Out]{
+		key:      cfg.key,
+		hasKey:   cfg.hasKey,
+		priority: cfg.priority,
+		listener: listener,
+	})
+	c.subscribers = append(subscribers, c.subscribers[i:]...)
+
+	return len(c.subscribers)
+}
+
+// RemoveListener removes a listener from the collector. It returns the number
+// of subscribers after the listener was removed. It returns -1 if the
+// listener was not found.
+func (c *Collector[In, Out]) RemoveListener(key SignalType) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, sub := range c.subscribers {
+		if sub.hasKey && sub.key == key {
+			subscribers := make([]collectorEntry[In, Out], 0, len(c.subscribers)-1)
+			subscribers = append(subscribers, c.subscribers[:i]...)
+			c.subscribers = append(subscribers, c.subscribers[i+1:]...)
+			return len(c.subscribers)
+		}
+	}
+
+	return -1
+}
+
+// Reset removes all the listeners from the collector.
+func (c *Collector[In, Out]) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscribers = nil
+}
+
+// Len returns the number of listeners subscribed to the collector.
+func (c *Collector[In, Out]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.subscribers)
+}
+
+// IsEmpty checks if the collector has any subscribers.
+func (c *Collector[In, Out]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+// Emit invokes all the listeners with the payload and returns their values in
+// the order of the listeners. It never returns a nil slice, so a collector
+// without listeners returns an empty slice.
+//
+// A panic of a listener is recovered and reported as a *PanicError in the
+// returned error; the value of that listener is left as the zero value of
+// Out. If the context is cancelled before all the listeners of an
+// asynchronous collector were started, Emit returns the context error joined
+// with the errors of the listeners already started.
+//
+// Example:
+//
+//	collector := signals.NewAsyncCollector[int, int]()
+//	collector.AddListener(func(ctx context.Context, payload int) int {
+//		return payload * 2
+//	})
+//	results, err := collector.Emit(context.Background(), 21) // [42], nil
+func (c *Collector[In, Out]) Emit(ctx context.Context, payload In) ([]Out, error) {
+	c.mu.RLock()
+	subscribers := c.subscribers
+	c.mu.RUnlock()
+
+	results := make([]Out, len(subscribers))
+	errs := make([]error, len(subscribers))
+	if !c.async {
+		for i, sub := range subscribers {
+			results[i], errs[i] = c.call(ctx, sub, payload)
+		}
+
+		return results, errors.Join(errs...)
+	}
+
+	var wg sync.WaitGroup
+	for i, sub := range subscribers {
+		if err := ctx.Err(); err != nil {
+			wg.Wait()
+			return results, errors.Join(append(errs, err)...)
+		}
+
+		wg.Add(1)
+		go func(i int, sub collectorEntry[In, Out]) {
+			defer wg.Done()
+			results[i], errs[i] = c.call(ctx, sub, payload)
+		}(i, sub)
+	}
+
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
+
+// call invokes the listener and recovers its panic as a *PanicError.
+func (c *Collector[In, Out]) call(ctx context.Context, sub collectorEntry[In, Out], payload In) (result Out, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = c.config.panicked(sub.key, recovered)
+		}
+	}()
+
+	return sub.listener(ctx, payload), nil
+}
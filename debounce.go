@@ -0,0 +1,264 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DebouncedSignal is a Signal that delays the emissions of the signal it
+// wraps until the activity settles. Each call to Emit replaces the pending
+// value and restarts the delay; the listeners are invoked with the latest
+// value once the delay elapses without a new emission. All the other methods
+// are provided by the wrapped signal.
+type DebouncedSignal[T any] struct {
+	Signal[T]
+
+	delay time.Duration
+
+	mu         sync.Mutex
+	timer      *time.Timer
+	generation uint64
+	pending    *pendingEmission[T]
+	closed     bool
+}
+
+// Debounce wraps the signal so its emissions are delayed until no new value
+// was emitted for the given delay.
+//
+// Example:
+//
+//	changed := signals.Debounce(signals.New[string](), 100*time.Millisecond)
+//	changed.AddListener(func(ctx context.Context, path string) {
+//	    // Called once the file system events settle
+//	})
+func Debounce[T any](s Signal[T], delay time.Duration) *DebouncedSignal[T] {
+	return &DebouncedSignal[T]{Signal: s, delay: delay}
+}
+
+// NewDebounced creates a new debounced signal that delivers the latest value
+// to its listeners synchronously, once no new value was emitted for the given
+// delay. The options are passed to NewSync.
+func NewDebounced[T any](delay time.Duration, opts ...SignalOption) *DebouncedSignal[T] {
+	return Debounce(NewSync[T](opts...), delay)
+}
+
+// Emit schedules the payload to be emitted once the delay elapses without a
+// new emission, replacing any pending payload. It returns immediately and is
+// safe to call from multiple goroutines; concurrent emissions coalesce into a
+// single delivery of the value emitted last.
+//
+// The payload is delivered with the context of the Emit call that scheduled
+// it. If that context is cancelled before the delay elapses, the payload is
+// dropped instead of being delivered late. The errors of the delayed emission
+// are not reported. If the signal was closed, Emit returns ErrSignalClosed.
+func (s *DebouncedSignal[T]) Emit(ctx context.Context, payload T) error {
+	return s.schedule(&pendingEmission[T]{payload: payload, ctx: ctx})
+}
+
+// EmitWithTimeout schedules the payload like Emit, with a context derived from
+// ctx that is cancelled after the given timeout. The timeout includes the
+// delay, so the payload is dropped if it cannot be delivered in time.
+func (s *DebouncedSignal[T]) EmitWithTimeout(ctx context.Context, payload T, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	if err := s.schedule(&pendingEmission[T]{payload: payload, ctx: ctx, cancel: cancel}); err != nil {
+		cancel()
+		return err
+	}
+
+	return nil
+}
+
+// EmitAndWait schedules the payload like Emit. Since the payload is delivered
+// after the delay, it returns as soon as the payload was scheduled.
+func (s *DebouncedSignal[T]) EmitAndWait(ctx context.Context, payload T) error {
+	return s.Emit(ctx, payload)
+}
+
+// EmitWithAck schedules the payload like Emit. Since the payload is delivered
+// after the delay, it returns a closed channel as soon as the payload was
+// scheduled.
+func (s *DebouncedSignal[T]) EmitWithAck(ctx context.Context, payload T) (<-chan Ack, error) {
+	return closedAcks(), s.Emit(ctx, payload)
+}
+
+// EmitFunc schedules the payload returned by produce like Emit, but only calls
+// produce if the wrapped signal has at least one listener. Otherwise, the
+// pending payload, if any, is kept, and EmitFunc returns nil, or
+// ErrNoListeners if the wrapped signal was created with
+// WithErrorOnNoListeners.
+func (s *DebouncedSignal[T]) EmitFunc(ctx context.Context, produce func() T) error {
+	if s.Signal.IsEmpty() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.closed {
+			return ErrSignalClosed
+		}
+
+		return noListeners(s.Signal)
+	}
+
+	return s.Emit(ctx, produce())
+}
+
+// EmitFactory schedules an emission like Emit. Once the delay elapses, the
+// values are built by factory and emitted with the EmitFactory method of the
+// wrapped signal. It returns ErrNotImplemented if the wrapped signal has no
+// EmitFactory method.
+func (s *DebouncedSignal[T]) EmitFactory(ctx context.Context, factory func() T) error {
+	if _, ok := s.Signal.(factoryEmitter[T]); !ok {
+		return ErrNotImplemented
+	}
+
+	return s.schedule(&pendingEmission[T]{ctx: ctx, factory: factory})
+}
+
+// EmitWithPriority schedules the payload like Emit. The priority is ignored,
+// since only the last value scheduled within the delay is delivered.
+func (s *DebouncedSignal[T]) EmitWithPriority(ctx context.Context, payload T, _ int) error {
+	return s.Emit(ctx, payload)
+}
+
+// MustEmit emits the payload like Emit, and panics with the error of Emit
+// if there is one.
+func (s *DebouncedSignal[T]) MustEmit(ctx context.Context, payload T) {
+	if err := s.Emit(ctx, payload); err != nil {
+		panic(err)
+	}
+}
+
+// EmitN schedules the payload like Emit. Since no listener is invoked before
+// the delay elapses, the count is always 0.
+func (s *DebouncedSignal[T]) EmitN(ctx context.Context, payload T) (int, error) {
+	return 0, s.Emit(ctx, payload)
+}
+
+// TryEmit schedules the payload like Emit, which never waits, and returns
+// true unless the signal was closed.
+func (s *DebouncedSignal[T]) TryEmit(ctx context.Context, payload T) (bool, error) {
+	err := s.Emit(ctx, payload)
+
+	return err == nil, err
+}
+
+// EmitBatch emits the values one after the other like Emit, and returns their
+// errors joined with errors.Join.
+func (s *DebouncedSignal[T]) EmitBatch(ctx context.Context, values []T) error {
+	var errs []error
+	for _, v := range values {
+		errs = append(errs, s.Emit(ctx, v))
+	}
+
+	return errors.Join(errs...)
+}
+
+// EmitMany emits the values one after the other like EmitBatch.
+func (s *DebouncedSignal[T]) EmitMany(ctx context.Context, values ...T) error {
+	return s.EmitBatch(ctx, values)
+}
+
+// CompareAndEmit schedules the new value like Emit. Once the delay elapses,
+// the new value is emitted with the CompareAndEmit method of the wrapped
+// signal, so it is compared with the value stored at that time, not when
+// CompareAndEmit is called. Since the comparison is delayed, CompareAndEmit
+// returns true as soon as the value was scheduled, and the result of the
+// delayed call is not reported. It returns ErrNotSticky right away if the
+// wrapped signal is not a StickySignal.
+func (s *DebouncedSignal[T]) CompareAndEmit(ctx context.Context, old, new T) (bool, error) {
+	if _, ok := s.Signal.(StickySignal[T]); !ok {
+		return false, ErrNotSticky
+	}
+
+	err := s.schedule(&pendingEmission[T]{payload: new, ctx: ctx, swap: true, old: old})
+
+	return err == nil, err
+}
+
+// EmitTagged schedules the payload like Emit. Once the delay elapses, the
+// payload is emitted with EmitTagged and the given tags.
+func (s *DebouncedSignal[T]) EmitTagged(ctx context.Context, payload T, tags ...string) error {
+	return s.schedule(&pendingEmission[T]{payload: payload, ctx: ctx, tagged: true, tags: tags})
+}
+
+// schedule replaces the pending emission and restarts the delay.
+func (s *DebouncedSignal[T]) schedule(p *pendingEmission[T]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrSignalClosed
+	}
+
+	s.stop().discard()
+	s.pending = p
+
+	generation := s.generation
+	s.timer = time.AfterFunc(s.delay, func() {
+		s.fire(generation)
+	})
+
+	return nil
+}
+
+// fire delivers the pending payload if no emission happened since the timer
+// of the given generation was started.
+func (s *DebouncedSignal[T]) fire(generation uint64) {
+	s.mu.Lock()
+	if generation != s.generation {
+		s.mu.Unlock()
+		return
+	}
+	p := s.stop()
+	s.mu.Unlock()
+
+	p.deliver(s.Signal)
+}
+
+// stop stops the timer and returns the pending emission, which is cleared. It
+// must be called with the lock held.
+func (s *DebouncedSignal[T]) stop() *pendingEmission[T] {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.generation++
+
+	p := s.pending
+	s.pending = nil
+
+	return p
+}
+
+// Reset drops the pending payload and resets the wrapped signal.
+func (s *DebouncedSignal[T]) Reset() {
+	s.mu.Lock()
+	p := s.stop()
+	s.mu.Unlock()
+
+	p.discard()
+	s.Signal.Reset()
+}
+
+// ResetAndCancel drops the pending payload and resets the wrapped signal with
+// its ResetAndCancel method.
+func (s *DebouncedSignal[T]) ResetAndCancel(ctx context.Context) error {
+	s.mu.Lock()
+	p := s.stop()
+	s.mu.Unlock()
+
+	p.discard()
+
+	return s.Signal.ResetAndCancel(ctx)
+}
+
+// Close delivers the pending payload right away, if there is one and its
+// context is not cancelled, and then closes the wrapped signal.
+func (s *DebouncedSignal[T]) Close() {
+	s.mu.Lock()
+	s.closed = true
+	p := s.stop()
+	s.mu.Unlock()
+
+	p.deliver(s.Signal)
+	s.Signal.Close()
+}
@@ -0,0 +1,66 @@
+package signals
+
+import "context"
+
+// DeltaListener is a listener that receives the value emitted before the
+// current one along with it, for change detection. The ok flag is false, and
+// prev is the zero value, when there is no previous value. It is added with
+// AddListenerDelta.
+type DeltaListener[T any] func(ctx context.Context, prev, curr T, ok bool)
+
+// delta is the previous value passed to the delta listeners of an emission.
+type delta[T any] struct {
+	prev T
+	ok   bool
+}
+
+// deltaKey is the context key of the delta of an emission.
+type deltaKey struct{}
+
+// AddListenerDelta adds a listener invoked with the previously emitted value
+// along with each emitted value. Once a delta listener was added, the signal
+// keeps the last emitted value, like a signal created with WithReplay does.
+// The first emission after that has no previous value: the listener receives
+// the zero value and false. Reset forgets the previous value. It accepts the
+// same options and has the same return values as AddListenerWith.
+//
+// The pair is taken atomically when the emission starts, so even with an
+// asynchronous signal emitted from multiple goroutines, prev is always the
+// value of the emission that started right before, and each value is the
+// previous value of exactly one emission. The listeners of concurrent
+// emissions may still run in any order. The values of EmitBatch are passed
+// one by one, each with the value before it as prev. The emissions of
+// EmitTagged are not tracked: they do not change the previous value, and the
+// listener receives them without one.
+//
+// Example:
+//
+//	signal.AddListenerDelta(func(ctx context.Context, prev, curr Status, ok bool) {
+//		if ok && prev != curr {
+//			log.Printf("status changed from %v to %v", prev, curr)
+//		}
+//	})
+func (s *BaseSignal[T]) AddListenerDelta(listener DeltaListener[T], opts ...ListenerOption) int {
+	sub := newSubscriber(func(ctx context.Context, payload T) error {
+		d, _ := ctx.Value(deltaKey{}).(delta[T])
+		listener(ctx, d.prev, payload, d.ok)
+		return nil
+	}, newListenerConfig(opts))
+	sub.batch = func(ctx context.Context, values []T) {
+		d, _ := ctx.Value(deltaKey{}).(delta[T])
+		for _, v := range values {
+			listener(ctx, d.prev, v, d.ok)
+			d = delta[T]{prev: v, ok: true}
+		}
+	}
+	sub.of(listener)
+
+	// The previous values are only recorded once a delta listener was
+	// actually added.
+	n := s.add(sub)
+	if n >= 0 {
+		s.deltas.Store(true)
+	}
+
+	return n
+}
@@ -0,0 +1,214 @@
+package signals
+
+import "context"
+
+// forwarder is the handler connecting a derived signal, or a sink, to its
+// source. Being a handler, it is removed with RemoveHandler without a key of
+// its own, so it never takes a key chosen by hand and is not reported by
+// Keys.
+type forwarder[T any] struct {
+	fn func(ctx context.Context, payload T)
+}
+
+// OnSignal implements Handler.
+func (f *forwarder[T]) OnSignal(ctx context.Context, payload T) {
+	f.fn(ctx, payload)
+}
+
+// Map returns a signal that emits fn(v), with the same context, whenever the
+// source signal emits v. The returned signal is synchronous, so its listeners
+// run as part of the emission of the source.
+//
+// The returned signal stays subscribed to the source until the returned close
+// function is called. The close function removes the connecting listener from
+// the source; it is idempotent. The errors of the returned signal are not
+// reported to the source. If the source refuses the connecting listener, for
+// instance because it has the maximum number of listeners set with
+// WithMaxListeners, Map returns a nil signal and a nil close function.
+//
+// Example:
+//
+//	names, closeNames := signals.Map(users, func(u User) string {
+//	    return u.Name
+//	})
+//	defer closeNames()
+//	names.AddListener(func(ctx context.Context, name string) {
+//	    // ...
+//	})
+func Map[A, B any](src Signal[A], fn func(A) B) (Signal[B], func()) {
+	dst := NewSync[B]()
+	h := &forwarder[A]{fn: func(ctx context.Context, payload A) {
+		_ = dst.Emit(ctx, fn(payload))
+	}}
+	if src.AddHandler(h) < 0 {
+		return nil, nil
+	}
+
+	return dst, func() {
+		src.RemoveHandler(h)
+	}
+}
+
+// Split returns a signal that emits every element of fn(v) in order, each with
+// the same context, whenever the source signal emits v. When fn returns an
+// empty slice, nothing is emitted. The returned signal is synchronous, so its
+// listeners run as part of the emission of the source, and each element is
+// delivered to all of them before the next one.
+//
+// The returned close function detaches the returned signal from the source;
+// it is idempotent. The errors of the returned signal are not reported to the
+// source. Like Map, Split returns a nil signal and a nil close function if the
+// source refuses the connecting listener.
+//
+// Example:
+//
+//	orders, closeOrders := signals.Split(batches, func(b Batch) []Order {
+//	    return b.Orders
+//	})
+//	defer closeOrders()
+//	orders.AddListener(func(ctx context.Context, order Order) {
+//	    // ...
+//	})
+func Split[A, B any](src Signal[A], fn func(A) []B) (Signal[B], func()) {
+	dst := NewSync[B]()
+	h := &forwarder[A]{fn: func(ctx context.Context, payload A) {
+		for _, v := range fn(payload) {
+			_ = dst.Emit(ctx, v)
+		}
+	}}
+	if src.AddHandler(h) < 0 {
+		return nil, nil
+	}
+
+	return dst, func() {
+		src.RemoveHandler(h)
+	}
+}
+
+// Merge returns a signal that emits every value emitted by any of the source
+// signals, with the same context. The returned signal is synchronous, so its
+// listeners run as part of the emission of the source; when the sources are
+// emitted concurrently, its listeners are invoked concurrently as well.
+// Merging no signals returns a valid signal that is never emitted by a source.
+//
+// The returned close function detaches the returned signal from all the
+// sources; it is idempotent. If one of the sources refuses the connecting
+// listener, Merge detaches the signal from the other sources and returns a
+// nil signal and a nil close function.
+//
+// Example:
+//
+//	events, closeEvents := signals.Merge(orders, payments, refunds)
+//	defer closeEvents()
+//	events.AddListener(func(ctx context.Context, event Event) {
+//	    // ...
+//	})
+func Merge[T any](sigs ...Signal[T]) (Signal[T], func()) {
+	dst := NewSync[T]()
+	h := &forwarder[T]{fn: func(ctx context.Context, payload T) {
+		_ = dst.Emit(ctx, payload)
+	}}
+	for i, src := range sigs {
+		if src.AddHandler(h) < 0 {
+			for _, added := range sigs[:i] {
+				added.RemoveHandler(h)
+			}
+			return nil, nil
+		}
+	}
+
+	return dst, func() {
+		for _, src := range sigs {
+			src.RemoveHandler(h)
+		}
+	}
+}
+
+// Pipe forwards every value emitted by the signal, with the same context, to
+// the destination signal. The optional onError callbacks are called with the
+// errors returned by the Emit method of the destination.
+//
+// The returned stop function removes the forwarding listener from the signal;
+// it is idempotent. Pipe returns nil, and forwards nothing, if the signal
+// refuses the listener, for instance because it has the maximum number of
+// listeners set with WithMaxListeners.
+//
+// Example:
+//
+//	stop := audit.Pipe(archive, func(err error) {
+//		log.Println("archive failed:", err)
+//	})
+//	defer stop()
+func (s *BaseSignal[T]) Pipe(dst Signal[T], onError ...func(error)) (stop func()) {
+	h := &forwarder[T]{fn: func(ctx context.Context, payload T) {
+		if err := dst.Emit(ctx, payload); err != nil {
+			for _, fn := range onError {
+				fn(err)
+			}
+		}
+	}}
+	if s.AddHandler(h) < 0 {
+		return nil
+	}
+
+	return func() {
+		s.RemoveHandler(h)
+	}
+}
+
+// AnySignal is a signal of type-erased values, which can receive the values
+// of signals of different types, for instance to log every emission of an
+// event bus in one place. See ToAny and Tap.
+type AnySignal = Signal[any]
+
+// ToAny returns a signal that emits every value emitted by the source signal,
+// converted to any, with the same context. Like with Map, the returned signal
+// is synchronous, the returned close function detaches it from the source,
+// and both are nil if the source refuses the connecting listener.
+//
+// Converting a value to any allocates a copy of it on the heap, unless the
+// value is a pointer, a map, a channel or a function, or a small value such
+// as a single-byte integer, so each emission of the source may cost one
+// allocation. The listeners of the source are not affected.
+//
+// Example:
+//
+//	erased, closeErased := signals.ToAny(payments)
+//	defer closeErased()
+//	erased.AddListener(func(ctx context.Context, value any) {
+//	    log.Printf("emitted %T: %v", value, value)
+//	})
+func ToAny[T any](src Signal[T]) (AnySignal, func()) {
+	return Map(src, func(v T) any {
+		return v
+	})
+}
+
+// Tap calls sink with every value emitted by the signal, converted to any,
+// and with the context of the emission, so the emissions of signals of
+// different types can be observed by a single function. The sink is invoked
+// like a listener of the signal, concurrently with the other listeners of an
+// asynchronous signal, and the other listeners still receive the typed value.
+// The returned stop function removes the sink; it is idempotent. Like Pipe,
+// Tap returns nil if the signal refuses the sink. The conversion to any may
+// allocate, as explained for ToAny.
+//
+// Example:
+//
+//	logEmission := func(ctx context.Context, value any) {
+//		log.Printf("emitted %T: %v", value, value)
+//	}
+//	defer orders.Tap(logEmission)()
+//	defer payments.Tap(logEmission)()
+func (s *BaseSignal[T]) Tap(sink func(ctx context.Context, value any)) (stop func()) {
+	h := &forwarder[T]{fn: func(ctx context.Context, payload T) {
+		sink(ctx, payload)
+	}}
+	if s.AddHandler(h) < 0 {
+		return nil
+	}
+
+	return func() {
+		s.RemoveHandler(h)
+	}
+}
@@ -0,0 +1,63 @@
+package signals
+
+// distinct remembers the last value delivered by a signal created with
+// WithEquality, to skip the values equal to it. It is protected by the lock of
+// the signal.
+type distinct[T any] struct {
+	equal func(a, b T) bool
+	last  T
+	set   bool
+}
+
+// filter removes from the emission every value equal to the value delivered
+// before it, and remembers the last remaining value. It returns false if no
+// value remains.
+func (d *distinct[T]) filter(e emission[T]) (emission[T], bool) {
+	if e.values == nil {
+		if d.set && d.equal(d.last, e.payload) {
+			return e, false
+		}
+		d.last, d.set = e.payload, true
+
+		return e, true
+	}
+
+	values := make([]T, 0, len(e.values))
+	for _, v := range e.values {
+		if d.set && d.equal(d.last, v) {
+			continue
+		}
+		d.last, d.set = v, true
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return e, false
+	}
+	e.payload, e.values = values[len(values)-1], values
+
+	return e, true
+}
+
+// reset forgets the last delivered value.
+func (d *distinct[T]) reset() {
+	var zero T
+	d.last, d.set = zero, false
+}
+
+// NewDistinct creates a new synchronous signal that invokes its listeners only
+// when the emitted value differs from the last delivered value, as compared
+// with ==. It is a shorthand for NewSync with the WithEquality option.
+//
+// Example:
+//
+//	status := signals.NewDistinct[Status]()
+//	status.AddListener(func(ctx context.Context, s Status) {
+//	    log.Println("status changed to", s)
+//	})
+//	status.Emit(ctx, Online)
+//	status.Emit(ctx, Online) // The listener is not invoked
+func NewDistinct[T comparable](opts ...SignalOption) Signal[T] {
+	return NewSync[T](append(opts[:len(opts):len(opts)], WithEquality(func(a, b T) bool {
+		return a == b
+	}))...)
+}
@@ -0,0 +1,162 @@
+package signals
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrNotImplemented is returned by the Emit method of a bare BaseSignal, which
+// has no way to invoke its listeners, as well as by the other methods emitting
+// a value on it. A type embedding BaseSignal must implement Emit itself. It is
+// also returned by the methods of a DebouncedSignal or a ThrottledSignal that
+// rely on a method the wrapped signal lacks, such as EmitFactory.
+var ErrNotImplemented = errors.New("signals: emit not implemented on base signal")
+
+// ErrSignalClosed is returned by Emit when the signal was closed with Close.
+var ErrSignalClosed = errors.New("signals: signal is closed")
+
+// ErrDropped is returned by Emit when a value was discarded because the buffer
+// of a signal created with WithBuffer was full.
+var ErrDropped = errors.New("signals: value dropped, the buffer is full")
+
+// ErrPaused is returned by Emit when a value was discarded because the signal
+// was paused with Pause and uses the PauseDrop policy.
+var ErrPaused = errors.New("signals: value dropped, the signal is paused")
+
+// ErrRateLimited is returned by Emit when the rate limit set with
+// WithRateLimit is exceeded. The listeners are not invoked.
+var ErrRateLimited = errors.New("signals: rate limit exceeded")
+
+// ErrPartialDelivery is matched by the *PartialDeliveryError returned by
+// EmitAndWait when its context is done before all the listeners returned.
+var ErrPartialDelivery = errors.New("signals: partial delivery")
+
+// PartialDeliveryError is the error returned by EmitAndWait when its context
+// is done while some listeners have not returned yet. It matches
+// ErrPartialDelivery with errors.Is, and unwraps to the context error, such as
+// context.DeadlineExceeded.
+type PartialDeliveryError struct {
+	// Finished is the number of listeners that returned in time.
+	Finished int
+
+	// Pending holds the keys of the listeners that did not return in time,
+	// including the ones that had not started yet, in the order of the
+	// listeners.
+	Pending []SignalType
+
+	// Err is the error of the context.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *PartialDeliveryError) Error() string {
+	return fmt.Sprintf("signals: partial delivery, %d listeners did not finish: %v", len(e.Pending), e.Err)
+}
+
+// Is reports whether the target is ErrPartialDelivery.
+func (e *PartialDeliveryError) Is(target error) bool {
+	return target == ErrPartialDelivery
+}
+
+// Unwrap returns the error of the context.
+func (e *PartialDeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// ErrGated is returned by Emit when the gate set with WithGate rejected the
+// value. The listeners are not invoked.
+var ErrGated = errors.New("signals: emission rejected by the gate")
+
+// ErrNotSticky is returned by CompareAndEmit when the signal does not store
+// its last value, that is when it was not created with WithReplay or
+// NewSticky, or when a DebouncedSignal or a ThrottledSignal wraps a signal
+// that is not a StickySignal.
+var ErrNotSticky = errors.New("signals: the signal does not store its last value")
+
+// ErrNoListeners is returned by Emit on a signal created with
+// WithErrorOnNoListeners when no listener accepted the emission.
+var ErrNoListeners = errors.New("signals: no listener for the emission")
+
+// ErrReentrantEmit is returned by the Emit methods of a signal created with
+// WithOrderedDelivery when one of its listeners emits on it with the context
+// of its invocation. Since each listener is invoked for one emission at a
+// time, the emission would wait forever for the listener emitting it, so none
+// of the listeners is invoked.
+var ErrReentrantEmit = errors.New("signals: ordered emission from one of its own listeners")
+
+// ErrListenerRefused is returned by WaitFor when the signal refuses the
+// temporary listener it waits with, for instance because the signal has the
+// maximum number of listeners set with WithMaxListeners.
+var ErrListenerRefused = errors.New("signals: the signal refused the listener")
+
+// ErrNoRoute is returned by Router.Emit when no key function was set with
+// Router.Route.
+var ErrNoRoute = errors.New("signals: the router has no route")
+
+// ErrAborted is matched by the *AbortError returned by StoppableSignal.Emit
+// when a listener aborted the emission.
+var ErrAborted = errors.New("signals: emission aborted")
+
+// AbortError is the error returned by StoppableSignal.Emit when a listener
+// added with AddAbortableListener aborted the emission. It matches ErrAborted
+// with errors.Is, and unwraps to the error returned by the listener.
+type AbortError struct {
+	// Key is the key of the listener that aborted the emission.
+	Key SignalType
+
+	// Err is the error returned by the listener.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *AbortError) Error() string {
+	return fmt.Sprintf("signals: emission aborted by listener %d: %v", e.Key, e.Err)
+}
+
+// Is reports whether the target is ErrAborted.
+func (e *AbortError) Is(target error) bool {
+	return target == ErrAborted
+}
+
+// Unwrap returns the error returned by the listener.
+func (e *AbortError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is the error reported by Emit when a listener panics. The panic
+// is recovered, so the remaining listeners are still invoked.
+type PanicError struct {
+	// Key is the key of the listener that panicked. A listener added without
+	// a key has a negative key generated by the package.
+	Key SignalType
+
+	// Value is the value recovered from the panic.
+	Value any
+
+	// Stack is the stack trace of the goroutine of the listener at the time
+	// of the panic, as formatted by runtime/debug.Stack.
+	Stack []byte
+}
+
+// newPanicError returns the *PanicError of the listener with the given key
+// that panicked with the recovered value. It must be called by the deferred
+// function that recovered the panic, so the stack trace includes the listener.
+func newPanicError(key SignalType, recovered any) *PanicError {
+	return &PanicError{Key: key, Value: recovered, Stack: debug.Stack()}
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("signals: listener %d panicked: %v", e.Key, e.Value)
+}
+
+// Unwrap returns the recovered value if it is an error, so errors.Is and
+// errors.As can look through the panic.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package signals
+
+import "time"
+
+// history is the ring of the last values emitted by a signal created with
+// WithHistory, along with the time each one was recorded. It is protected by
+// the lock of the signal.
+type history[T any] struct {
+	items []T
+	times []time.Time
+	head  int
+	size  int
+}
+
+// newHistory creates a ring keeping the last n values.
+func newHistory[T any](n int) history[T] {
+	n = max(n, 0)
+
+	return history[T]{items: make([]T, n), times: make([]time.Time, n)}
+}
+
+// add appends the value, replacing the oldest one if the ring is full.
+func (h *history[T]) add(v T) {
+	if len(h.items) == 0 {
+		return
+	}
+
+	i := (h.head + h.size) % len(h.items)
+	h.items[i], h.times[i] = v, time.Now()
+	if h.size < len(h.items) {
+		h.size++
+	} else {
+		h.head = (h.head + 1) % len(h.items)
+	}
+}
+
+// values returns a copy of the values, oldest first.
+func (h *history[T]) values() []T {
+	values := make([]T, h.size)
+	for i := range values {
+		values[i] = h.items[(h.head+i)%len(h.items)]
+	}
+
+	return values
+}
+
+// since returns a copy of the values recorded after t, oldest first.
+func (h *history[T]) since(t time.Time) []T {
+	values := make([]T, 0, h.size)
+	for i := 0; i < h.size; i++ {
+		j := (h.head + i) % len(h.items)
+		if h.times[j].After(t) {
+			values = append(values, h.items[j])
+		}
+	}
+
+	return values
+}
+
+// clone returns a copy of the ring, with the same capacity and timestamps.
+func (h *history[T]) clone() history[T] {
+	return history[T]{
+		items: append([]T(nil), h.items...),
+		times: append([]time.Time(nil), h.times...),
+		head:  h.head,
+		size:  h.size,
+	}
+}
+
+// History returns the last values emitted by a signal created with
+// WithHistory, oldest first. It returns an empty slice for a signal without
+// history. The returned slice is a copy that the caller is free to modify.
+//
+// Example:
+//
+//	signal := signals.NewSync[int](signals.WithHistory(3))
+//	for i := 1; i <= 5; i++ {
+//		signal.Emit(ctx, i)
+//	}
+//	fmt.Println(signal.History()) // [3 4 5]
+func (s *BaseSignal[T]) History() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.history.values()
+}
+
+// HistorySince returns the values of the history of a signal created with
+// WithHistory that were emitted after t, oldest first, for instance to catch
+// up a client reconnecting with the time of the last value it saw. The time
+// of a value is when it was delivered, which for a buffered or paused signal
+// can be later than the call to Emit.
+//
+// The history only keeps the last values: if more values than its capacity
+// were emitted since t, the oldest ones are lost and HistorySince returns the
+// ones that remain. Compare the result with the expected count, or keep a
+// larger history, if the loss matters. It returns an empty slice for a signal
+// without history.
+//
+// Example:
+//
+//	signal := signals.New[Event](signals.WithHistory(1000))
+//	// ...
+//	for _, e := range signal.HistorySince(client.LastSeen) {
+//		client.Send(e)
+//	}
+func (s *BaseSignal[T]) HistorySince(t time.Time) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.history.since(t)
+}
@@ -0,0 +1,113 @@
+package signals
+
+import (
+	"context"
+	"sync"
+)
+
+// inflight counts the running invocations of a listener, so
+// RemoveListenerAndWait can wait for them and CancelListener can cancel them.
+type inflight struct {
+	mu     sync.Mutex
+	n      int
+	idle   chan struct{}
+	closed bool
+
+	// cancels holds the functions cancelling the context of each running
+	// invocation, by the token returned by begin.
+	cancels map[uint64]context.CancelFunc
+	next    uint64
+}
+
+// begin registers an invocation whose context is cancelled by cancel, and
+// returns the token to pass to end. It returns false if the listener was
+// detached by close, in which case it must not be invoked.
+func (f *inflight) begin(cancel context.CancelFunc) (token uint64, ok bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return 0, false
+	}
+
+	if f.n == 0 {
+		f.idle = make(chan struct{})
+	}
+	f.n++
+
+	if f.cancels == nil {
+		f.cancels = make(map[uint64]context.CancelFunc)
+	}
+	f.next++
+	f.cancels[f.next] = cancel
+
+	return f.next, true
+}
+
+// end marks the invocation registered with begin under the token as
+// finished.
+func (f *inflight) end(token uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.cancels, token)
+	f.n--
+	if f.n == 0 {
+		close(f.idle)
+	}
+}
+
+// busy returns the number of invocations in progress.
+func (f *inflight) busy() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.n
+}
+
+// cancel cancels the context of the invocations in progress, and returns how
+// many there are.
+func (f *inflight) cancel() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, cancel := range f.cancels {
+		cancel()
+	}
+
+	return len(f.cancels)
+}
+
+// close prevents further invocations, and waits until the running ones
+// finished or the context is done, in which case it returns the context
+// error.
+func (f *inflight) close(ctx context.Context) error {
+	f.detach()
+
+	return f.wait(ctx)
+}
+
+// detach prevents further invocations.
+func (f *inflight) detach() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+}
+
+// wait blocks until the running invocations finished or the context is done,
+// in which case it returns the context error.
+func (f *inflight) wait(ctx context.Context) error {
+	f.mu.Lock()
+	if f.n == 0 {
+		f.mu.Unlock()
+		return nil
+	}
+	idle := f.idle
+	f.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
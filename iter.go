@@ -0,0 +1,62 @@
+//go:build go1.23
+
+package signals
+
+import (
+	"context"
+	"iter"
+)
+
+// iterBufferSize is the capacity of the subscription behind Iter.
+const iterBufferSize = 64
+
+// Iter returns an iterator over the values emitted by the signal, for use
+// with a range loop. The iterator subscribes to the signal when the loop
+// starts, yields each emitted value, and stops when the context is cancelled.
+// Breaking out of the loop removes the subscription right away. Each loop
+// over the iterator has its own subscription.
+//
+// Like Subscribe, which it uses, the iterator never blocks the emissions: up
+// to 64 values are kept while the body of the loop runs, and the values
+// emitted beyond that are dropped for this iterator. Use AddListener when
+// every value must be processed.
+//
+// Example:
+//
+//	for event := range signal.Iter(ctx) {
+//		if event.Type == "shutdown" {
+//			break
+//		}
+//		handle(event)
+//	}
+func (s *BaseSignal[T]) Iter(ctx context.Context) iter.Seq[T] {
+	return subscription(ctx, s.Subscribe)
+}
+
+// Iter returns an iterator over the values emitted by the signal, like the
+// Iter method of the signal types, for a signal held as a Signal interface.
+//
+// Example:
+//
+//	var signal signals.Signal[Event] = signals.New[Event]()
+//	for event := range signals.Iter(ctx, signal) {
+//		handle(event)
+//	}
+func Iter[T any](ctx context.Context, s Signal[T]) iter.Seq[T] {
+	return subscription(ctx, s.Subscribe)
+}
+
+// subscription returns an iterator over the values of a subscription made
+// with subscribe, which is removed once the loop over the iterator ends.
+func subscription[T any](ctx context.Context, subscribe func(ctx context.Context, bufferSize int) <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		for v := range subscribe(ctx, iterBufferSize) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+//go:build go1.23
+
+package signals_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linux019/signals"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIter(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int]()
+	base := testSignal.(*signals.SyncSignal[int])
+
+	go func() {
+		for i := 1; ; i++ {
+			for testSignal.IsEmpty() {
+				time.Sleep(time.Millisecond)
+			}
+			if err := testSignal.Emit(ctx, i); err != nil {
+				return
+			}
+			if i == 3 {
+				testSignal.Close()
+			}
+		}
+	}()
+
+	var received []int
+	for v := range base.Iter(ctx) {
+		received = append(received, v)
+		if v == 3 {
+			break
+		}
+	}
+	require.Equal(t, []int{1, 2, 3}, received)
+	require.Eventually(t, testSignal.IsEmpty, time.Second, time.Millisecond)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	for range signals.Iter(cancelled, signals.NewSync[int]()) {
+		t.Fatal("no value expected")
+	}
+}
@@ -0,0 +1,133 @@
+package signals
+
+import (
+	"math"
+	"sync"
+)
+
+// interned holds the keys converted to a SignalType by KeyOf, in both
+// directions, and the number of keys converted so far.
+var interned = struct {
+	mu     sync.RWMutex
+	keys   map[any]SignalType
+	values map[SignalType]any
+	count  int
+}{keys: make(map[any]SignalType), values: make(map[SignalType]any)}
+
+// KeyOf returns the SignalType standing for a key of any comparable type, such
+// as a string, so a listener can be keyed by a readable name instead of an
+// int. It returns the same SignalType for equal keys of the same type, and
+// different ones for the other keys, including the equal values of different
+// types. The returned SignalType is negative, and taken from its own range
+// among the lowest values of SignalType, so it never equals a key generated
+// for a listener added without a key, and is far below the keys chosen by
+// hand. A listener added under a key returned by KeyOf is reported by Keys
+// like any listener added with a key.
+//
+// The keys passed to KeyOf are kept for the lifetime of the program, so KeyOf
+// is meant for a bounded set of keys, such as constants. KeyValue returns the
+// key a SignalType stands for.
+//
+// Example:
+//
+//	signal.AddListener(sendWelcomeEmail, signals.KeyOf("user.created"))
+//	signal.RemoveListener(signals.KeyOf("user.created"))
+func KeyOf[K comparable](key K) SignalType {
+	interned.mu.RLock()
+	k, ok := interned.keys[key]
+	interned.mu.RUnlock()
+	if ok {
+		return k
+	}
+
+	interned.mu.Lock()
+	defer interned.mu.Unlock()
+	if k, ok = interned.keys[key]; !ok {
+		interned.count++
+		k = SignalType(math.MinInt/2 + interned.count)
+		interned.keys[key] = k
+		interned.values[k] = key
+	}
+
+	return k
+}
+
+// KeyValue returns the key that the given SignalType stands for, if it was
+// returned by KeyOf, for instance to log the readable key of a listener. It
+// returns false for any other SignalType.
+//
+// Example:
+//
+//	signals.WithOnPanic(func(recovered any, key signals.SignalType) {
+//		name, _ := signals.KeyValue(key)
+//		log.Printf("listener %v panicked: %v", name, recovered)
+//	})
+func KeyValue(key SignalType) (any, bool) {
+	interned.mu.RLock()
+	defer interned.mu.RUnlock()
+
+	v, ok := interned.values[key]
+
+	return v, ok
+}
+
+// AddListenerKeyed adds a listener to the signal under a key of any
+// comparable type, like AddListener with the SignalType returned by KeyOf for
+// the key. It returns -1 and adds nothing if a listener was already added
+// under the same key, and the listener is removed with
+// RemoveListener(KeyOf(key)).
+//
+// Example:
+//
+//	signals.AddListenerKeyed(signal, sendWelcomeEmail, "user.created")
+func AddListenerKeyed[K comparable, T any](s Signal[T], listener SignalListener[T], key K, opts ...ListenerOption) int {
+	return s.AddListenerWith(listener, append(opts[:len(opts):len(opts)], KeyOf(key))...)
+}
+
+// Key is the key of a listener receiving payloads of type T. Declaring the
+// keys of a program as typed constants gives them a readable name, and ties
+// each of them to the type of its signal, so the compiler rejects a key used
+// with a signal, or a listener, of another type:
+//
+//	const (
+//		WelcomeEmail signals.Key[User]  = 1
+//		Invoice      signals.Key[Order] = 2
+//	)
+//
+//	WelcomeEmail.AddTo(userCreated, sendWelcomeEmail)
+//	WelcomeEmail.AddTo(orderPlaced, sendWelcomeEmail) // Does not compile
+type Key[T any] SignalType
+
+// Type returns the SignalType of the key, for the methods of Signal taking a
+// SignalType, and to compare with the keys reported by the signal, such as
+// PanicError.Key.
+func (k Key[T]) Type() SignalType {
+	return SignalType(k)
+}
+
+// AddTo adds the listener to the signal under the key, like AddListener with
+// the SignalType of the key, and returns the number of listeners of the
+// signal. It returns -1 and adds nothing if the signal already has a listener
+// with the same key.
+//
+// Example:
+//
+//	WelcomeEmail.AddTo(userCreated, func(ctx context.Context, u User) {
+//		mailer.SendWelcome(ctx, u.Email)
+//	})
+func (k Key[T]) AddTo(s Signal[T], listener SignalListener[T], opts ...ListenerOption) int {
+	return s.AddListenerWith(listener, append(opts[:len(opts):len(opts)], k.Type())...)
+}
+
+// RemoveFrom removes the listener with the key from the signal, like
+// RemoveListener, and returns the number of listeners left, or -1 if the
+// signal has no listener with the key.
+func (k Key[T]) RemoveFrom(s Signal[T]) int {
+	return s.RemoveListener(k.Type())
+}
+
+// AddedTo reports whether the signal has a listener with the key, like
+// HasListener.
+func (k Key[T]) AddedTo(s Signal[T]) bool {
+	return s.HasListener(k.Type())
+}
@@ -0,0 +1,43 @@
+package signals
+
+import "context"
+
+// metaKey is the context key of the metadata of type M. Each type M has its
+// own key, so metadata of different types do not overwrite each other.
+type metaKey[M any] struct{}
+
+// WithMeta returns a copy of ctx carrying the metadata, such as the ID of the
+// request that caused an emission. Emit passes its context to the listeners,
+// which retrieve the metadata with MetaFrom. The metadata is keyed by its
+// type: a context holds one value of each type, and attaching another value
+// of the same type hides the previous one. Define a dedicated type for each
+// kind of metadata rather than using a basic type such as string.
+//
+// Example:
+//
+//	type RequestMeta struct {
+//		RequestID string
+//		UserID    int
+//	}
+//
+//	ctx = signals.WithMeta(ctx, RequestMeta{RequestID: id, UserID: user})
+//	signal.Emit(ctx, event)
+func WithMeta[M any](ctx context.Context, meta M) context.Context {
+	return context.WithValue(ctx, metaKey[M]{}, meta)
+}
+
+// MetaFrom returns the metadata of type M attached to ctx with WithMeta, and
+// whether there is one.
+//
+// Example:
+//
+//	signal.AddListener(func(ctx context.Context, event Event) {
+//		if meta, ok := signals.MetaFrom[RequestMeta](ctx); ok {
+//			log.Printf("request %s: %v", meta.RequestID, event)
+//		}
+//	})
+func MetaFrom[M any](ctx context.Context) (M, bool) {
+	meta, ok := ctx.Value(metaKey[M]{}).(M)
+
+	return meta, ok
+}
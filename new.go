@@ -1,5 +1,7 @@
 package signals
 
+import "runtime"
+
 // NewSync creates a new signal that can be used to emit and listen to events
 // synchronously.
 //
@@ -11,9 +13,19 @@ package signals
 //	    // ...
 //	})
 //	signal.Emit(context.Background(), 42)
-func NewSync[T any]() Signal[T] {
+func NewSync[T any](opts ...SignalOption) Signal[T] {
+	return newSync[T](opts)
+}
+
+// newSync creates a new SyncSignal configured with the options.
+func newSync[T any](opts []SignalOption) *SyncSignal[T] {
+	return newSyncConfig[T](newSignalConfig(opts))
+}
+
+// newSyncConfig creates a new SyncSignal with the given configuration.
+func newSyncConfig[T any](cfg signalConfig) *SyncSignal[T] {
 	s := &SyncSignal[T]{}
-	s.Reset()
+	s.init(cfg, s)
 
 	return s
 }
@@ -29,9 +41,186 @@ func NewSync[T any]() Signal[T] {
 //	    // ...
 //	})
 //	signal.Emit(context.Background(), 42)
-func New[T any]() Signal[T] {
+func New[T any](opts ...SignalOption) Signal[T] {
+	return newAsync[T](opts)
+}
+
+// newAsync creates a new AsyncSignal configured with the options.
+func newAsync[T any](opts []SignalOption) *AsyncSignal[T] {
+	return newAsyncConfig[T](newSignalConfig(opts))
+}
+
+// newAsyncConfig creates a new AsyncSignal with the given configuration.
+func newAsyncConfig[T any](cfg signalConfig) *AsyncSignal[T] {
 	s := &AsyncSignal[T]{}
-	s.Reset() // Reset the signal
+	s.init(cfg, s)
+	if s.config.maxConcurrency > 0 {
+		s.slots = make(chan struct{}, s.config.maxConcurrency)
+	}
 
 	return s
 }
+
+// NewResult creates a new signal whose listeners return an error. The signal
+// emits synchronously, and Emit returns the errors of all the failed listeners
+// joined with errors.Join, or nil if all of them succeeded.
+//
+// Example:
+//
+//	signal := signals.NewResult[int]()
+//	signal.AddListener(func(ctx context.Context, payload int) error {
+//	    // Listener implementation
+//	    // ...
+//	    return nil
+//	})
+//	err := signal.Emit(context.Background(), 42)
+func NewResult[T any](opts ...SignalOption) *ResultSignal[T] {
+	s := newSync[T](opts)
+
+	return &ResultSignal[T]{Signal: s, base: &s.BaseSignal}
+}
+
+// NewAsyncResult creates a new signal whose listeners return an error, like
+// NewResult, but invokes the listeners concurrently like New. Emit waits for
+// all the listeners and joins their errors in the order of the listeners,
+// whatever the order in which they failed, so the returned error is
+// deterministic.
+//
+// Example:
+//
+//	signal := signals.NewAsyncResult[int]()
+//	signal.AddListener(func(ctx context.Context, payload int) error {
+//	    // Listener implementation
+//	    // ...
+//	    return nil
+//	})
+//	err := signal.EmitAndWait(context.Background(), 42)
+func NewAsyncResult[T any](opts ...SignalOption) *ResultSignal[T] {
+	s := newAsync[T](opts)
+
+	return &ResultSignal[T]{Signal: s, base: &s.BaseSignal}
+}
+
+// NewParallel creates a new signal that invokes its listeners on at most
+// workers goroutines at once, a middle ground between NewSync, which invokes
+// them one after the other, and New, which invokes them all at once. Like
+// with NewSync, Emit returns only once all the listeners returned, which
+// suits CPU-bound listeners that benefit from parallelism without starting a
+// goroutine per listener. The listeners are started in the order of their
+// priority as workers become available. The bound is shared by the emissions
+// running concurrently. A value of workers <= 0 means runtime.GOMAXPROCS(0).
+//
+// It is a shorthand for New with the WithMaxConcurrency option, which it
+// overrides.
+//
+// Example:
+//
+//	signal := signals.NewParallel[Image](4)
+//	signal.AddListener(func(ctx context.Context, img Image) {
+//	    // Runs alongside at most 3 other listeners
+//	})
+//	signal.Emit(context.Background(), img) // Returns once all the listeners returned
+func NewParallel[T any](workers int, opts ...SignalOption) Signal[T] {
+	return newAsync[T](parallelOptions(workers, opts))
+}
+
+// NewParallelResult creates a new signal whose listeners return an error,
+// like NewAsyncResult, but invokes them on at most workers goroutines at once
+// like NewParallel. Emit joins the errors of the listeners in the order of
+// the listeners.
+//
+// Example:
+//
+//	signal := signals.NewParallelResult[Image](4)
+//	signal.AddListener(func(ctx context.Context, img Image) error {
+//	    // Listener implementation
+//	    // ...
+//	    return nil
+//	})
+//	err := signal.Emit(context.Background(), img)
+func NewParallelResult[T any](workers int, opts ...SignalOption) *ResultSignal[T] {
+	s := newAsync[T](parallelOptions(workers, opts))
+
+	return &ResultSignal[T]{Signal: s, base: &s.BaseSignal}
+}
+
+// parallelOptions returns the options with the concurrency limit of a signal
+// created with NewParallel.
+func parallelOptions(workers int, opts []SignalOption) []SignalOption {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	return append(opts[:len(opts):len(opts)], WithMaxConcurrency(workers))
+}
+
+// NewStoppable creates a new synchronous signal whose listeners can stop the
+// propagation of an emission by returning false.
+//
+// Example:
+//
+//	signal := signals.NewStoppable[Request]()
+//	signal.AddListener(func(ctx context.Context, req Request) bool {
+//	    return req.Authorized
+//	}, signals.WithPriority(100))
+//	ran, err := signal.Emit(context.Background(), req)
+func NewStoppable[T any](opts ...SignalOption) *StoppableSignal[T] {
+	return newStoppableConfig[T](newSignalConfig(opts))
+}
+
+// newStoppableConfig creates a new StoppableSignal with the given
+// configuration.
+func newStoppableConfig[T any](cfg signalConfig) *StoppableSignal[T] {
+	s := &StoppableSignal[T]{}
+	s.init(cfg, s)
+
+	return s
+}
+
+// NewSticky creates a new synchronous signal that remembers the last emitted
+// value and replays it to every listener added after the first emission. It
+// is a shorthand for NewSync with the WithReplay option, returned as a
+// StickySignal so its value can be updated with CompareAndEmit.
+//
+// Example:
+//
+//	config := signals.NewSticky[Config]()
+//	config.Emit(context.Background(), Config{Debug: true})
+//	config.AddListener(func(ctx context.Context, payload Config) {
+//	    // Immediately called with Config{Debug: true}
+//	})
+func NewSticky[T any](opts ...SignalOption) StickySignal[T] {
+	return newSync[T](append(opts[:len(opts):len(opts)], WithReplay()))
+}
+
+// serialBufferSize is the default capacity of the buffer of a signal created
+// with NewSerial.
+const serialBufferSize = 1024
+
+// NewSerial creates a new signal whose listeners all run on a single
+// background goroutine, one emission after the other in the order of the
+// emissions, so the listeners need no locking of their own even when Emit is
+// called from multiple goroutines. It is a middle ground between NewSync,
+// which runs the listeners in the goroutine of Emit, and New, which runs them
+// concurrently.
+//
+// Emit returns as soon as the payload is queued. The queue holds 1024 values
+// and Emit waits for room when it is full; pass WithBuffer to change its
+// capacity or its overflow policy. Like with WithBuffer, the listeners receive
+// the values of the context of the emission, but not its cancellation. Close
+// stops accepting new values while the queued ones are still delivered, and
+// Wait waits for them.
+//
+// Example:
+//
+//	signal := signals.NewSerial[Event]()
+//	signal.AddListener(func(ctx context.Context, event Event) {
+//	    counts[event.Type]++ // Never runs concurrently
+//	})
+//	signal.Emit(context.Background(), event)
+//	// ...
+//	signal.Close()
+//	signal.Wait(shutdownCtx)
+func NewSerial[T any](opts ...SignalOption) Signal[T] {
+	return NewSync[T](append([]SignalOption{WithBuffer(serialBufferSize, Block)}, opts...)...)
+}
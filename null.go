@@ -0,0 +1,209 @@
+package signals
+
+import (
+	"context"
+	"time"
+)
+
+// NullSignal is a signal that discards everything, intended as a stand-in for
+// a signal in tests. Its emit methods always succeed without doing anything,
+// and its methods adding a listener add nothing and return 0. Create it with
+// NewNull.
+type NullSignal[T any] struct{}
+
+// NewNull creates a signal that discards every emitted value and every added
+// listener.
+//
+// Example:
+//
+//	svc := NewService(signals.NewNull[Event]())
+//	svc.Run(ctx) // The events emitted by the service go nowhere
+func NewNull[T any]() Signal[T] {
+	return NullSignal[T]{}
+}
+
+// Emit does nothing and returns nil.
+func (NullSignal[T]) Emit(context.Context, T) error { return nil }
+
+// EmitWithTimeout does nothing and returns nil.
+func (NullSignal[T]) EmitWithTimeout(context.Context, T, time.Duration) error { return nil }
+
+// EmitWithPriority does nothing and returns nil.
+func (NullSignal[T]) EmitWithPriority(context.Context, T, int) error { return nil }
+
+// MustEmit does nothing.
+func (NullSignal[T]) MustEmit(context.Context, T) {}
+
+// EmitBatch does nothing and returns nil.
+func (NullSignal[T]) EmitBatch(context.Context, []T) error { return nil }
+
+// EmitMany does nothing and returns nil.
+func (NullSignal[T]) EmitMany(context.Context, ...T) error { return nil }
+
+// EmitTagged does nothing and returns nil.
+func (NullSignal[T]) EmitTagged(context.Context, T, ...string) error { return nil }
+
+// EmitAndWait does nothing and returns nil.
+func (NullSignal[T]) EmitAndWait(context.Context, T) error { return nil }
+
+// EmitWithAck does nothing and returns a closed channel and nil.
+func (NullSignal[T]) EmitWithAck(context.Context, T) (<-chan Ack, error) {
+	return closedAcks(), nil
+}
+
+// AddListener does nothing and returns 0.
+func (NullSignal[T]) AddListener(SignalListener[T], ...SignalType) int { return 0 }
+
+// AddListenerWith does nothing and returns 0.
+func (NullSignal[T]) AddListenerWith(SignalListener[T], ...ListenerOption) int { return 0 }
+
+// AddListenerWithID does nothing and returns 0 and 0.
+func (NullSignal[T]) AddListenerWithID(SignalListener[T], ...ListenerOption) (ListenerID, int) {
+	return 0, 0
+}
+
+// On does nothing and returns a function that does nothing.
+func (NullSignal[T]) On(SignalListener[T], ...ListenerOption) func() { return func() {} }
+
+// AddBatchListener does nothing and returns 0.
+func (NullSignal[T]) AddBatchListener(BatchListener[T], ...ListenerOption) int { return 0 }
+
+// AddListenerDelta does nothing and returns 0.
+func (NullSignal[T]) AddListenerDelta(DeltaListener[T], ...ListenerOption) int { return 0 }
+
+// AddListenerCtx does nothing and returns 0.
+func (NullSignal[T]) AddListenerCtx(context.Context, SignalListener[T], ...ListenerOption) int {
+	return 0
+}
+
+// AddHandler does nothing and returns 0.
+func (NullSignal[T]) AddHandler(Handler[T], ...ListenerOption) int { return 0 }
+
+// RemoveHandler does nothing and returns 0.
+func (NullSignal[T]) RemoveHandler(Handler[T]) int { return 0 }
+
+// ReplaceListener does nothing and returns false.
+func (NullSignal[T]) ReplaceListener(SignalListener[T], SignalType, ...ListenerOption) bool {
+	return false
+}
+
+// AddListenerOnce does nothing and returns 0.
+func (NullSignal[T]) AddListenerOnce(SignalListener[T], ...ListenerOption) int { return 0 }
+
+// AddListenerFiltered does nothing and returns 0.
+func (NullSignal[T]) AddListenerFiltered(SignalListener[T], func(T) bool, ...ListenerOption) int {
+	return 0
+}
+
+// AddCloseListener does nothing and returns false: the listener is never
+// invoked.
+func (NullSignal[T]) AddCloseListener(func(context.Context)) bool { return false }
+
+// RemoveListenerAndWait does nothing and returns 0 and nil.
+func (NullSignal[T]) RemoveListenerAndWait(context.Context, SignalType) (int, error) {
+	return 0, nil
+}
+
+// CancelListener does nothing and returns 0.
+func (NullSignal[T]) CancelListener(SignalType) int { return 0 }
+
+// RemoveGroup does nothing and returns 0.
+func (NullSignal[T]) RemoveGroup(string) int { return 0 }
+
+// Use does nothing.
+func (NullSignal[T]) Use(...Middleware[T]) {}
+
+// HasListener returns false.
+func (NullSignal[T]) HasListener(SignalType) bool { return false }
+
+// Keys returns nil.
+func (NullSignal[T]) Keys() []SignalType { return nil }
+
+// Subscribe returns a channel that never receives a value, and is closed once
+// the context is cancelled.
+func (NullSignal[T]) Subscribe(ctx context.Context, _ int) <-chan T {
+	ch := make(chan T)
+	context.AfterFunc(ctx, func() { close(ch) })
+
+	return ch
+}
+
+// SubscribeWithHistory returns a channel like Subscribe.
+func (n NullSignal[T]) SubscribeWithHistory(ctx context.Context, size int) <-chan T {
+	return n.Subscribe(ctx, size)
+}
+
+// SubscribeWithDrops returns two channels that never receive anything, and
+// are closed once the context is cancelled.
+func (NullSignal[T]) SubscribeWithDrops(ctx context.Context, _ int) (<-chan T, <-chan struct{}) {
+	ch, drops := make(chan T), make(chan struct{})
+	context.AfterFunc(ctx, func() {
+		close(ch)
+		close(drops)
+	})
+
+	return ch, drops
+}
+
+// WaitFor blocks until the context is done, and returns the zero value and
+// the context error.
+func (NullSignal[T]) WaitFor(ctx context.Context, _ func(T) bool) (T, error) {
+	<-ctx.Done()
+
+	var zero T
+	return zero, ctx.Err()
+}
+
+// Pipe does nothing and returns a function that does nothing.
+func (NullSignal[T]) Pipe(Signal[T], ...func(error)) func() { return func() {} }
+
+// Tap does nothing and returns a function that does nothing.
+func (NullSignal[T]) Tap(func(context.Context, any)) func() { return func() {} }
+
+// RemoveListener does nothing and returns 0.
+func (NullSignal[T]) RemoveListener(SignalType) int { return 0 }
+
+// Clone returns a new NullSignal.
+func (NullSignal[T]) Clone() Signal[T] { return NullSignal[T]{} }
+
+// Reset does nothing.
+func (NullSignal[T]) Reset() {}
+
+// ResetAndCancel does nothing and returns nil.
+func (NullSignal[T]) ResetAndCancel(context.Context) error { return nil }
+
+// Close does nothing.
+func (NullSignal[T]) Close() {}
+
+// Wait returns nil.
+func (NullSignal[T]) Wait(context.Context) error { return nil }
+
+// History returns nil.
+func (NullSignal[T]) History() []T { return nil }
+
+// HistorySince returns nil.
+func (NullSignal[T]) HistorySince(time.Time) []T { return nil }
+
+// Pause does nothing.
+func (NullSignal[T]) Pause() {}
+
+// Resume does nothing.
+func (NullSignal[T]) Resume() {}
+
+// IsPaused returns false.
+func (NullSignal[T]) IsPaused() bool { return false }
+
+// QueueDepth returns 0.
+func (NullSignal[T]) QueueDepth() int { return 0 }
+
+// Drain returns nil.
+func (NullSignal[T]) Drain() []T { return nil }
+
+// Len returns 0.
+func (NullSignal[T]) Len() int { return 0 }
+
+// IsEmpty returns true.
+func (NullSignal[T]) IsEmpty() bool { return true }
+
+// Stats returns zero counters.
+func (NullSignal[T]) Stats() Stats { return Stats{} }
@@ -0,0 +1,151 @@
+package signals
+
+import (
+	"errors"
+	"time"
+)
+
+// Observer receives notifications about the activity of a signal, for
+// instance to maintain metrics. It is set with WithObserver. The methods are
+// called synchronously, possibly from multiple goroutines at once, so they
+// must be fast and safe for concurrent use.
+type Observer interface {
+	// OnEmit is called at the start of each emission with the number of
+	// listeners subscribed to the signal.
+	OnEmit(n int)
+
+	// OnListenerStart is called right before a listener is invoked. A
+	// listener added without a key is reported with a negative key generated
+	// when it was added, which stays the same across emissions.
+	OnListenerStart(key SignalType)
+
+	// OnListenerEnd is called right after a listener returned, with the
+	// wall-clock time it took to run. The time an asynchronous invocation
+	// spent waiting to start is not included; see QueueObserver.
+	OnListenerEnd(key SignalType, d time.Duration)
+}
+
+// ErrorObserver is an optional interface of an Observer. If the observer
+// implements it, OnListenerError is called whenever a listener returns an
+// error or panics, in which case err is a *PanicError.
+type ErrorObserver interface {
+	OnListenerError(key SignalType, err error)
+}
+
+// QueueObserver is an optional interface of an Observer. If the observer
+// implements it, OnListenerQueued is called before each invocation of an
+// asynchronous signal with the time the invocation waited to start, which
+// includes the time blocked on the limit set by WithMaxConcurrency and, with
+// WithOrderedDelivery, the time spent behind the previous invocations of the
+// listener.
+type QueueObserver interface {
+	OnListenerQueued(key SignalType, wait time.Duration)
+}
+
+// BreakerObserver is an optional interface of an Observer. If the observer
+// implements it, OnBreakerStateChange is called whenever the circuit breaker
+// of a listener of a signal created with WithCircuitBreaker changes state.
+type BreakerObserver interface {
+	OnBreakerStateChange(key SignalType, state BreakerState)
+}
+
+// EvictionObserver is an optional interface of an Observer. If the observer
+// implements it, OnListenerEvicted is called whenever a listener of a signal
+// created with WithSlowListenerEviction is removed for being too slow.
+type EvictionObserver interface {
+	OnListenerEvicted(key SignalType)
+}
+
+// BreakerState is the state of the circuit breaker of a listener.
+type BreakerState int
+
+const (
+	// BreakerClosed means the listener is invoked as usual.
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen means the listener failed too many times in a row and is
+	// skipped until the cooldown elapsed.
+	BreakerOpen
+
+	// BreakerHalfOpen means the cooldown elapsed and the listener is invoked
+	// once to find out whether it recovered.
+	BreakerHalfOpen
+)
+
+// String returns the name of the state.
+func (b BreakerState) String() string {
+	switch b {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// OutcomeStatus tells what happened to a listener during an emission.
+type OutcomeStatus int
+
+const (
+	// OutcomeRan means the listener was invoked and succeeded.
+	OutcomeRan OutcomeStatus = iota
+
+	// OutcomeSkipped means the listener was not invoked, because its filter
+	// or its tags did not match the emission, because the context it is bound
+	// to with AddListenerCtx is done, because it was added with
+	// AddListenerOnce and was already invoked, or because its circuit breaker
+	// set with WithCircuitBreaker is open.
+	OutcomeSkipped
+
+	// OutcomeFailed means the listener was invoked and returned an error.
+	OutcomeFailed
+
+	// OutcomePanicked means the listener was invoked and panicked.
+	OutcomePanicked
+)
+
+// String returns the name of the status.
+func (o OutcomeStatus) String() string {
+	switch o {
+	case OutcomeRan:
+		return "ran"
+	case OutcomeSkipped:
+		return "skipped"
+	case OutcomeFailed:
+		return "failed"
+	case OutcomePanicked:
+		return "panicked"
+	default:
+		return "unknown"
+	}
+}
+
+// Outcome is what WithOutcomeCallback reports for a listener of an emission.
+type Outcome struct {
+	Status OutcomeStatus
+
+	// Err is the error of a failed listener, or the *PanicError of a listener
+	// that panicked.
+	Err error
+
+	// Recovered is the value recovered from the panic of a listener that
+	// panicked.
+	Recovered any
+}
+
+// outcomeOf returns the outcome of an invocation that returned err.
+func outcomeOf(err error) Outcome {
+	if err == nil {
+		return Outcome{Status: OutcomeRan}
+	}
+
+	var panicked *PanicError
+	if errors.As(err, &panicked) {
+		return Outcome{Status: OutcomePanicked, Err: err, Recovered: panicked.Value}
+	}
+
+	return Outcome{Status: OutcomeFailed, Err: err}
+}
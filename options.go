@@ -0,0 +1,713 @@
+package signals
+
+import (
+	"context"
+	"time"
+)
+
+// ListenerOption configures a listener while it is being added to a signal.
+// A SignalType is itself a ListenerOption, so a key can be passed to
+// AddListener alongside any other option.
+//
+// Example:
+//
+//	signal := signals.NewSync[int]()
+//	signal.AddListener(func(ctx context.Context, payload int) {
+//		// Listener implementation
+//		// ...
+//	}, signals.SignalType(1), signals.WithPriority(10))
+type ListenerOption interface {
+	applyListener(cfg *listenerConfig)
+}
+
+// listenerConfig holds the settings collected from the listener options.
+type listenerConfig struct {
+	key      SignalType
+	hasKey   bool
+	priority int
+	timeout  time.Duration
+	tags     []string
+}
+
+// listenerOptionFunc adapts a function to the ListenerOption interface.
+type listenerOptionFunc func(cfg *listenerConfig)
+
+func (f listenerOptionFunc) applyListener(cfg *listenerConfig) {
+	f(cfg)
+}
+
+// applyListener makes SignalType usable as a ListenerOption that sets the
+// key of the listener.
+func (k SignalType) applyListener(cfg *listenerConfig) {
+	cfg.key = k
+	cfg.hasKey = true
+}
+
+// WithPriority sets the priority of the listener. Listeners with a higher
+// priority are invoked before listeners with a lower priority. Listeners
+// sharing the same priority are invoked in the order they were added. The
+// default priority is 0. An asynchronous signal starts the goroutines of its
+// listeners in that order, but does not wait for a listener to finish before
+// starting the next one, so the order in which they run is best-effort.
+//
+// Example:
+//
+//	signal := signals.NewSync[int]()
+//	signal.AddListener(persist)
+//	signal.AddListener(validate, signals.WithPriority(10)) // Runs before persist
+func WithPriority(priority int) ListenerOption {
+	return listenerOptionFunc(func(cfg *listenerConfig) {
+		cfg.priority = priority
+	})
+}
+
+// WithListenerTimeout bounds each invocation of the listener. The listener
+// receives a context derived from the emission context with an additional
+// deadline after the given timeout, so the cancellation of the emission
+// context still propagates to it. When the emission context has an earlier
+// deadline, that deadline wins: the shorter of the two always applies.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	signal.AddListener(slowListener, signals.WithListenerTimeout(50*time.Millisecond))
+func WithListenerTimeout(timeout time.Duration) ListenerOption {
+	return listenerOptionFunc(func(cfg *listenerConfig) {
+		cfg.timeout = timeout
+	})
+}
+
+// WithTags adds the listener to the given groups. A tagged listener is invoked
+// by Emit like any other listener, and also by EmitTagged when one of its tags
+// is given.
+//
+// Example:
+//
+//	signal.AddListener(listener, signals.WithTags("audit", "metrics"))
+func WithTags(tags ...string) ListenerOption {
+	return listenerOptionFunc(func(cfg *listenerConfig) {
+		cfg.tags = append(cfg.tags, tags...)
+	})
+}
+
+// newListenerConfig applies the given options to a fresh listenerConfig.
+func newListenerConfig(opts []ListenerOption) listenerConfig {
+	var cfg listenerConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyListener(&cfg)
+		}
+	}
+
+	return cfg
+}
+
+// SignalOption configures a signal while it is being created.
+//
+// Example:
+//
+//	signal := signals.NewSync[int](signals.WithOnPanic(func(recovered any, key signals.SignalType) {
+//		log.Printf("listener %d panicked: %v", key, recovered)
+//	}))
+type SignalOption func(cfg *signalConfig)
+
+// signalConfig holds the settings collected from the signal options.
+type signalConfig struct {
+	onPanic        func(recovered any, key SignalType)
+	onPanicError   func(err *PanicError)
+	replay         bool
+	maxConcurrency int
+	ordered        bool
+	bufferSize     int
+	overflow       OverflowPolicy
+	observer       Observer
+	errorObserver  ErrorObserver
+	queueObserver  QueueObserver
+	pausePolicy    PausePolicy
+	retries        int
+	backoff        func(attempt int) time.Duration
+	maxListeners   int
+	rate           float64
+	burst          int
+	history        int
+	equal          any
+	onOutcome      func(key SignalType, outcome Outcome)
+	lifo           bool
+	gate           any
+
+	breakerFailures int
+	breakerCooldown time.Duration
+	breakerObserver BreakerObserver
+
+	slowThreshold    time.Duration
+	slowStrikes      int
+	evictionObserver EvictionObserver
+
+	decorator func(ctx context.Context, key SignalType) context.Context
+
+	dedup     bool
+	validator any
+
+	errorOnNoListeners bool
+	isolated           bool
+
+	selection SelectionPolicy
+	backlog   int
+
+	defaultTimeout time.Duration
+
+	// coalesce builds the state of each signal created with WithCoalesce, so
+	// the clones of a signal do not share it.
+	coalesce any
+}
+
+// WithOnPanic sets a hook that is called with the recovered value and the key
+// of the listener whenever a listener panics. The panic is recovered in any
+// case and reported by Emit as a *PanicError; the hook only adds a way to
+// observe it as soon as it happens. Use WithOnPanicError to get the stack
+// trace of the panic as well.
+func WithOnPanic(fn func(recovered any, key SignalType)) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.onPanic = fn
+	}
+}
+
+// WithOnPanicError sets a hook that is called with the *PanicError of a
+// listener whenever it panics, which holds the key of the listener, the
+// recovered value and the stack trace of the panic. Like the hook of
+// WithOnPanic, which can be set as well, it is called from the goroutine of
+// the listener right after the panic was recovered, so it must be safe for
+// concurrent use with an asynchronous signal. The number of panics is also
+// counted by Stats.
+//
+// Example:
+//
+//	signal := signals.New[int](signals.WithOnPanicError(func(err *signals.PanicError) {
+//		log.Printf("listener %d panicked: %v\n%s", err.Key, err.Value, err.Stack)
+//	}))
+func WithOnPanicError(fn func(err *PanicError)) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.onPanicError = fn
+	}
+}
+
+// panicked returns the *PanicError of the listener with the given key that
+// panicked with the recovered value, after calling the panic hooks.
+func (cfg *signalConfig) panicked(key SignalType, recovered any) *PanicError {
+	err := newPanicError(key, recovered)
+	if cfg.onPanic != nil {
+		cfg.onPanic(recovered, key)
+	}
+	if cfg.onPanicError != nil {
+		cfg.onPanicError(err)
+	}
+
+	return err
+}
+
+// WithOutcomeCallback sets a callback that is called with the outcome of
+// each listener of each emission: whether it ran, was skipped, failed or
+// panicked, along with the error or the recovered value. It is called right
+// after the listener returned, or when the listener is skipped, from the
+// goroutine that invoked it, so it must be safe for concurrent use with an
+// asynchronous signal. A listener invoked for each value of a batch reports
+// one outcome per value.
+//
+// Example:
+//
+//	signal := signals.New[Order](signals.WithOutcomeCallback(func(key signals.SignalType, o signals.Outcome) {
+//		audit.Record(key, o.Status.String(), o.Err)
+//	}))
+func WithOutcomeCallback(fn func(key SignalType, outcome Outcome)) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.onOutcome = fn
+	}
+}
+
+// WithObserver sets an observer notified of the emissions of the signal and
+// of the invocations of its listeners. If the observer also implements
+// ErrorObserver, QueueObserver, BreakerObserver or EvictionObserver, it is
+// notified of the failed invocations, of the time the asynchronous
+// invocations waited to start, of the state changes of the circuit breakers
+// or of the evictions of slow listeners as well. Without an observer the
+// signal does no extra work.
+//
+// Example:
+//
+//	signal := signals.New[int](signals.WithObserver(metrics))
+func WithObserver(obs Observer) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.observer = obs
+		cfg.errorObserver, _ = obs.(ErrorObserver)
+		cfg.queueObserver, _ = obs.(QueueObserver)
+		cfg.breakerObserver, _ = obs.(BreakerObserver)
+		cfg.evictionObserver, _ = obs.(EvictionObserver)
+	}
+}
+
+// WithCircuitBreaker gives every listener of the signal a circuit breaker, so
+// a listener that keeps failing, for instance because the downstream it calls
+// is broken, is not invoked again and again. After the given number of
+// consecutive failed invocations, an error or a panic, the breaker of the
+// listener opens: the listener is skipped for the cooldown. The first emission
+// after the cooldown is a trial. If the listener succeeds, the breaker closes
+// and the listener is invoked again as usual; if it fails, the breaker opens
+// for another cooldown. The emissions concurrent with the trial skip the
+// listener. With WithRetry, an invocation fails only if all its attempts fail.
+//
+// The breakers do not apply to the listeners added with AddListenerOnce. If
+// the observer set with WithObserver implements BreakerObserver, it is
+// notified whenever a breaker changes state. A value of failures <= 0
+// disables the breakers, which is the default.
+//
+// Example:
+//
+//	signal := signals.NewAsyncResult[Order](signals.WithCircuitBreaker(5, 30*time.Second))
+func WithCircuitBreaker(failures int, cooldown time.Duration) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.breakerFailures = failures
+		cfg.breakerCooldown = cooldown
+	}
+}
+
+// WithSlowListenerEviction removes the listeners that become too slow, so
+// stuck listeners do not accumulate on the signal. A listener whose invocations
+// take longer than threshold for the given number of consecutive invocations
+// is removed from the signal, as if by RemoveListener, and reported to the
+// OnListenerEvicted method of the observer set with WithObserver, if it
+// implements EvictionObserver. An invocation that takes less than threshold
+// resets the count of the listener.
+//
+// Only the time the listener runs is measured, from the start of its
+// invocation to its return, including the retries set with WithRetry: the
+// time an invocation of an asynchronous signal waits for a concurrency slot
+// (see WithMaxConcurrency), or behind the previous invocations of the
+// listener with WithOrderedDelivery, is not counted. The invocation that
+// reaches the count runs to completion; the emissions that started before
+// the eviction may still invoke the listener. A once listener is never
+// evicted. A value of strikes < 1 counts as 1, and a threshold <= 0 means no
+// eviction, which is the default.
+//
+// Example:
+//
+//	signal := signals.New[Event](
+//		signals.WithSlowListenerEviction(time.Second, 3),
+//		signals.WithObserver(metrics), // Counts the evicted listeners
+//	)
+func WithSlowListenerEviction(threshold time.Duration, strikes int) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.slowThreshold = threshold
+		cfg.slowStrikes = max(strikes, 1)
+	}
+}
+
+// WithHistory makes the signal keep the last n emitted values, which History
+// returns oldest first, for instance for debugging or for consumers joining
+// late. Unlike WithReplay, the values are not delivered to the new listeners.
+// Reset clears the history. A value of n <= 0 means no history, which is the
+// default.
+//
+// Example:
+//
+//	signal := signals.New[Event](signals.WithHistory(100))
+func WithHistory(n int) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.history = n
+	}
+}
+
+// WithValidator makes the signal check the value of each emission with
+// validate before anything else is done with it. If validate returns an
+// error, the emission is dropped: no listener is invoked, and Emit returns
+// that error as is. This keeps the validation of the payloads in one place
+// rather than in every listener. The validator runs before the gate set with
+// WithGate, which never sees an invalid value, and before the rate limit set
+// with WithRateLimit, so an invalid value takes no token. It applies to all
+// the methods emitting a value; EmitBatch validates all the values first, and
+// returns the first error without emitting any of them. The option is ignored
+// by the signals whose type parameter is not T.
+//
+// Example:
+//
+//	signal := signals.New[Order](signals.WithValidator(func(o Order) error {
+//		if o.Quantity <= 0 {
+//			return fmt.Errorf("invalid quantity %d", o.Quantity)
+//		}
+//		return nil
+//	}))
+func WithValidator[T any](validate func(v T) error) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.validator = validate
+	}
+}
+
+// WithGate makes the signal evaluate gate once at the start of each emission,
+// with the context and the value of the emission, and drop the emission when
+// gate returns false: no listener is invoked and Emit returns ErrGated. It
+// is a cheaper and clearer way to turn an emission off, for instance behind a
+// feature flag, than a filter on every listener. The gate applies to all the
+// methods emitting a value, including EmitTagged, before the tags are
+// considered, and EmitBatch, which only keeps the values the gate accepts. A
+// dropped emission takes no token of WithRateLimit. The option is ignored by
+// the signals whose type parameter is not T.
+//
+// Example:
+//
+//	signal := signals.New[Event](signals.WithGate(func(ctx context.Context, e Event) bool {
+//		return flags.Enabled(ctx, "events")
+//	}))
+func WithGate[T any](gate func(ctx context.Context, v T) bool) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.gate = gate
+	}
+}
+
+// WithEquality makes the signal skip the emissions of a value equal to the
+// last delivered value, as reported by equal, so the listeners are only
+// invoked when the value changed, for instance for the types that are not
+// comparable with ==. The first emission is always delivered, and so is the
+// first one after Reset. A value skipped this way is not replayed nor added to
+// the history. The values of a batch are compared one after the other, and the
+// emissions of EmitTagged are never skipped. The option is ignored by the
+// signals whose type parameter is not T.
+//
+// Example:
+//
+//	signal := signals.New[[]string](signals.WithEquality(slices.Equal[[]string]))
+func WithEquality[T any](equal func(a, b T) bool) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.equal = equal
+	}
+}
+
+// WithLIFO makes the signal invoke the listeners sharing the same priority in
+// the reverse order they were added, so the listener added last runs first,
+// like the handlers of an undo stack. The priorities (see WithPriority) still
+// apply first: LIFO only breaks the ties. The order only matters to the
+// signals invoking their listeners one after the other, such as the ones
+// created with NewSync, and Keys, which lists the keys in that order; it does
+// not change which listeners are invoked, nor Len or the removal of the
+// listeners.
+//
+// Example:
+//
+//	undo := signals.NewSync[Edit](signals.WithLIFO())
+func WithLIFO() SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.lifo = true
+	}
+}
+
+// WithReplay makes the signal remember the last emitted value. A listener
+// added after the first emission is immediately invoked with that value, before
+// AddListener returns. Nothing is replayed until the signal was emitted at
+// least once, so a listener never receives a value that was not emitted.
+// Reset forgets the stored value.
+func WithReplay() SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.replay = true
+	}
+}
+
+// WithMaxConcurrency limits the number of listener invocations an
+// asynchronous signal runs at the same time. Once the limit is reached, Emit
+// blocks until a running invocation finishes, or until its context is
+// cancelled, before starting the next one. The number of goroutines started
+// by the signal therefore never exceeds n. A value of n <= 0 means no limit,
+// which is the default. The option is ignored by synchronous signals.
+//
+// Example:
+//
+//	signal := signals.New[int](signals.WithMaxConcurrency(8))
+func WithMaxConcurrency(n int) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.maxConcurrency = n
+	}
+}
+
+// WithOrderedDelivery makes an asynchronous signal deliver the emitted values
+// to each listener in the order the emissions started. Every listener gets
+// its own queue: a listener is never invoked for an emission before its
+// invocation for the previous emission returned, while different listeners
+// still run concurrently. All the listeners therefore observe the same
+// sequence of values, even when Emit is called from multiple goroutines. The
+// option is ignored by synchronous signals, which always deliver in order.
+//
+// A listener must not wait for an emission of the signal it listens to, since
+// that emission waits for the listener to return first. An emission made by a
+// listener with the context it received, directly or through other signals,
+// is detected and fails with ErrReentrantEmit; an emission made with another
+// context deadlocks. A listener can still emit from a new goroutine it does
+// not wait for, or on a signal created with WithBuffer.
+//
+// Example:
+//
+//	signal := signals.New[State](signals.WithOrderedDelivery())
+func WithOrderedDelivery() SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.ordered = true
+	}
+}
+
+// WithBuffer makes the signal queue the emitted values in a buffer of the
+// given capacity instead of invoking the listeners during Emit. A background
+// goroutine delivers the buffered values one after the other, in the order
+// they were emitted, unless EmitWithPriority gave them a priority; each value
+// is delivered like the signal delivers an emission, so the listeners of an
+// asynchronous signal still run concurrently. Emit returns as soon as the
+// value is buffered, and the policy decides what happens when the buffer is
+// full.
+//
+// Since the values are delivered after Emit returned, the context of the
+// emission is detached from its cancellation: the listeners receive its
+// values but not its deadline. The context only bounds the wait of the Block
+// policy. Wait waits for the buffered values to be delivered.
+//
+// Example:
+//
+//	signal := signals.New[Event](signals.WithBuffer(1024, signals.DropOldest))
+//	if err := signal.Emit(ctx, event); errors.Is(err, signals.ErrDropped) {
+//		log.Println("listeners are falling behind")
+//	}
+func WithBuffer(capacity int, policy OverflowPolicy) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.bufferSize = capacity
+		cfg.overflow = policy
+	}
+}
+
+// WithPausePolicy sets what the signal does with the values emitted while it
+// is paused with Pause. The default policy is PauseDrop.
+//
+// Example:
+//
+//	signal := signals.New[Job](signals.WithPausePolicy(signals.PauseReplay))
+func WithPausePolicy(policy PausePolicy) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.pausePolicy = policy
+	}
+}
+
+// WithRetry makes the signal invoke a listener again when it returns an
+// error, up to the given number of retries, which is mostly useful with the
+// listeners of NewResult. Before retry number attempt, starting at 1, the
+// signal waits for backoff(attempt); a nil backoff retries right away. The
+// retries stop early once the context of the emission is done, and only the
+// error of the last invocation is reported. A listener that panics is not
+// retried.
+//
+// Example:
+//
+//	signal := signals.NewResult[Event](signals.WithRetry(3, func(attempt int) time.Duration {
+//		return time.Duration(attempt) * 100 * time.Millisecond
+//	}))
+func WithRetry(attempts int, backoff func(attempt int) time.Duration) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.retries = attempts
+		cfg.backoff = backoff
+	}
+}
+
+// WithMaxListeners limits the number of listeners of the signal to n, as a
+// guard against the listeners added in a loop by mistake. Once the limit is
+// reached, adding a listener fails and returns ListenerLimitReached, which is
+// distinct from the -1 returned for a duplicate key, so Len never exceeds n.
+// A value of n <= 0 means no limit, which is the default.
+//
+// Example:
+//
+//	signal := signals.New[int](signals.WithMaxListeners(100))
+//	if signal.AddListener(listener) == signals.ListenerLimitReached {
+//		log.Println("too many listeners")
+//	}
+func WithMaxListeners(n int) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.maxListeners = n
+	}
+}
+
+// WithRateLimit limits the rate of the emissions of the signal with a token
+// bucket refilled with rate tokens per second and holding up to burst tokens.
+// Each emission takes a token; when there is none, Emit returns
+// ErrRateLimited right away without invoking any listener. A call to
+// EmitBatch takes a single token. A rate <= 0 means no limit, which is the
+// default.
+//
+// Emit never waits for a token. A caller that prefers to wait can retry after
+// a delay when Emit returns ErrRateLimited, or pace its emissions with a
+// limiter of its own, such as golang.org/x/time/rate, and leave the signal
+// unlimited.
+//
+// Example:
+//
+//	signal := signals.New[Event](signals.WithRateLimit(100, 10))
+//	if err := signal.Emit(ctx, event); errors.Is(err, signals.ErrRateLimited) {
+//		http.Error(w, "slow down", http.StatusTooManyRequests)
+//	}
+func WithRateLimit(rate float64, burst int) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.rate = rate
+		cfg.burst = burst
+	}
+}
+
+// WithContextDecorator sets a function that derives the context of each
+// listener invocation from the context of the emission, for instance to start
+// a tracing span per listener. It is called right before each invocation, on
+// the goroutine running the listener, with the key of the listener. The
+// listener, and the middlewares wrapping it, receive the returned context,
+// which must be derived from ctx so the values, deadline and cancellation of
+// the emission context remain accessible. If it returns nil, the emission
+// context is used. A timeout set with WithListenerTimeout applies to the
+// decorated context.
+//
+// Example:
+//
+//	signal := signals.New[Order](signals.WithContextDecorator(func(ctx context.Context, key signals.SignalType) context.Context {
+//		return context.WithValue(ctx, listenerKey{}, key)
+//	}))
+func WithContextDecorator(fn func(ctx context.Context, key SignalType) context.Context) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.decorator = fn
+	}
+}
+
+// WithDedup makes the signal ignore a listener whose function was already
+// added, so registering the same handler twice by mistake does not make it
+// run twice per emission. The method adding the listener returns -1 and adds
+// nothing, as if the key was already used. The listeners added with AddHandler
+// are not concerned: a Handler is already added at most once.
+//
+// Go cannot compare functions, so the functions are compared by their code
+// pointer, as returned by reflect.Value.Pointer. This tells apart the
+// functions declared separately, but not the closures created by the same
+// function literal, nor the method values of the same method bound to
+// different receivers: those share their code and count as duplicates even
+// though they capture different variables or receivers.
+//
+// Example:
+//
+//	signal := signals.New[Event](signals.WithDedup())
+//	signal.AddListener(audit)
+//	signal.AddListener(audit) // Returns -1, audit runs once per emission
+func WithDedup() SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.dedup = true
+	}
+}
+
+// WithIsolation isolates the listeners of a synchronous signal from each
+// other. Every signal recovers the panics of the listeners themselves; with
+// the option, a synchronous signal also recovers a panic raised while
+// delivering the value to a listener, but outside of it, such as in the
+// filter of a listener added with AddListenerFiltered or in the methods of
+// the Observer. The panic is reported as a *PanicError of that listener,
+// joined into the error of Emit, and the emission continues with the next
+// listener. Without the option, such a panic propagates to the caller of
+// Emit, and the next listeners are not invoked. The option is ignored by
+// asynchronous signals.
+//
+// Example:
+//
+//	clicked := signals.NewSync[Click](signals.WithIsolation())
+func WithIsolation() SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.isolated = true
+	}
+}
+
+// WithErrorOnNoListeners makes Emit return ErrNoListeners when no listener
+// accepted the emission, so an emission nobody hears, often the sign of a
+// misconfiguration, can be logged. A listener accepts an emission unless its
+// filter or its tags exclude it, or the context it is bound to with
+// AddListenerCtx is done; the check is made when the emission is dispatched.
+// By default, Emit returns nil when there is no listener.
+//
+// The error is returned by all the methods emitting a value, except that a
+// value queued by a signal created with WithBuffer, or held by a paused
+// signal, is dispatched after Emit returned, so the error is not reported.
+//
+// Example:
+//
+//	signal := signals.New[Event](signals.WithErrorOnNoListeners())
+//	if err := signal.Emit(ctx, event); errors.Is(err, signals.ErrNoListeners) {
+//		log.Printf("nobody handles %v", event)
+//	}
+func WithErrorOnNoListeners() SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.errorOnNoListeners = true
+	}
+}
+
+// WithSelection sets how a signal created with NewQueue chooses the listener
+// receiving each emission. The default policy is RoundRobin. The option is
+// ignored by the other signals, which deliver each emission to all their
+// listeners.
+//
+// Example:
+//
+//	jobs := signals.NewQueue[Job](signals.WithSelection(signals.LeastBusy))
+func WithSelection(policy SelectionPolicy) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.selection = policy
+	}
+}
+
+// WithBacklog makes a signal created with NewQueue keep up to n values
+// emitted while no listener accepts them, instead of returning
+// ErrNoListeners. The values are delivered in the order they were emitted as
+// soon as a listener accepting them is added, in the goroutine adding it.
+// Once the backlog holds n values, Emit returns ErrDropped. The values of the
+// backlog are not delivered by Close and not waited for by Wait until a
+// listener takes them, and Reset drops them. A value of n <= 0 means no backlog, which is the default. The
+// option is ignored by the other signals.
+//
+// Example:
+//
+//	jobs := signals.NewQueue[Job](signals.WithBacklog(100))
+//	jobs.Emit(ctx, job) // Kept until a worker is added
+func WithBacklog(n int) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.backlog = n
+	}
+}
+
+// WithDefaultTimeout bounds every emission of the signal: the emitting
+// methods, such as Emit, TryEmit, EmitBatch or EmitAndWait, run with a context
+// derived from the caller's context with a deadline after the given timeout.
+// The listeners receive the derived context, and a blocking emission, such as
+// one waiting for room in a buffer created with the Block policy, gives up
+// with context.DeadlineExceeded once the deadline passes.
+//
+// The earliest deadline always applies. When the caller's context already has
+// a deadline before the default one, that deadline is kept unchanged, so
+// EmitWithTimeout, or a context created with context.WithTimeout, can only
+// shorten the default timeout, never extend it. A timeout set with
+// WithListenerTimeout further bounds the invocations of its listener.
+//
+// The values queued by a signal created with WithBuffer, or held by a paused
+// signal, are delivered after the emission returned, without a deadline, like
+// with any emission context. A timeout <= 0 means no default timeout, which is
+// the default.
+//
+// Example:
+//
+//	signal := signals.New[int](signals.WithDefaultTimeout(2 * time.Second))
+//	signal.Emit(context.Background(), 42) // The listeners get a 2s deadline
+func WithDefaultTimeout(timeout time.Duration) SignalOption {
+	return func(cfg *signalConfig) {
+		cfg.defaultTimeout = timeout
+	}
+}
+
+// newSignalConfig applies the given options to a fresh signalConfig.
+func newSignalConfig(opts []SignalOption) signalConfig {
+	var cfg signalConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	return cfg
+}
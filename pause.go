@@ -0,0 +1,124 @@
+package signals
+
+import (
+	"context"
+	"sync"
+)
+
+// PausePolicy decides what a paused signal does with the emitted values.
+type PausePolicy int
+
+const (
+	// PauseDrop discards the values emitted while the signal is paused. Emit
+	// returns ErrPaused.
+	PauseDrop PausePolicy = iota
+
+	// PauseReplay keeps the values emitted while the signal is paused, and
+	// delivers them in order when the signal is resumed. Emit returns nil as
+	// soon as the value is stored.
+	PauseReplay
+)
+
+// pauseState records whether a signal is paused and the values it keeps until
+// it is resumed.
+type pauseState[T any] struct {
+	mu      sync.Mutex
+	paused  bool
+	pending []bufferedEmission[T]
+
+	// resume serializes the calls to Resume, so the kept values are replayed
+	// only once and in order.
+	resume sync.Mutex
+}
+
+// Pause stops the delivery of the emitted values until Resume is called. The
+// listeners stay subscribed, so Len and HasListener are not affected. What
+// Emit does while the signal is paused depends on the policy set with
+// WithPausePolicy: by default the values are dropped. The emissions already in
+// progress complete normally. Pause is idempotent.
+//
+// Example:
+//
+//	signal.Pause()
+//	defer signal.Resume()
+//	// maintenance
+func (s *BaseSignal[T]) Pause() {
+	s.pause.mu.Lock()
+	defer s.pause.mu.Unlock()
+	s.pause.paused = true
+}
+
+// Resume restarts the delivery of the emitted values after Pause. With the
+// PauseReplay policy, the values emitted while the signal was paused are
+// delivered first, in the order they were emitted, before Resume returns; the
+// values emitted during the replay are delivered after them. Resume does
+// nothing if the signal is not paused.
+func (s *BaseSignal[T]) Resume() {
+	s.pause.resume.Lock()
+	defer s.pause.resume.Unlock()
+
+	for {
+		s.pause.mu.Lock()
+		pending := s.pause.pending
+		s.pause.pending = nil
+		if len(pending) == 0 {
+			s.pause.paused = false
+			s.pause.mu.Unlock()
+			return
+		}
+		s.pause.mu.Unlock()
+
+		for _, item := range pending {
+			s.activity.hold()
+			_ = s.send(item.ctx, s.impl, item.value)
+			s.activity.end()
+		}
+	}
+}
+
+// IsPaused reports whether the signal was paused with Pause and not resumed
+// yet.
+func (s *BaseSignal[T]) IsPaused() bool {
+	s.pause.mu.Lock()
+	defer s.pause.mu.Unlock()
+
+	return s.pause.paused
+}
+
+// held reports whether the emission must not be delivered because the signal is
+// paused, in which case it is kept or dropped according to the pause policy.
+// The returned error is the one Emit reports.
+func (s *BaseSignal[T]) held(ctx context.Context, e emission[T]) (bool, error) {
+	s.pause.mu.Lock()
+	defer s.pause.mu.Unlock()
+	if !s.pause.paused {
+		return false, nil
+	}
+
+	if s.config.pausePolicy == PauseDrop {
+		s.stats.dropped.Add(1)
+		return true, ErrPaused
+	}
+
+	s.pause.pending = append(s.pause.pending, bufferedEmission[T]{
+		ctx:   detach(ctx),
+		value: e,
+	})
+
+	return true, nil
+}
+
+// drain removes the values kept while the signal is paused and returns them in
+// the order they were emitted.
+func (p *pauseState[T]) drain() []T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	values := make([]T, 0, len(p.pending))
+	for _, item := range p.pending {
+		values = append(values, item.value.payload)
+	}
+	p.pending = nil
+
+	return values
+}
@@ -0,0 +1,60 @@
+package signals
+
+import "context"
+
+// pendingEmission is a payload waiting to be delivered by a DebouncedSignal or
+// a ThrottledSignal, along with the context of the emission that produced it.
+type pendingEmission[T any] struct {
+	payload T
+	ctx     context.Context
+
+	// tagged marks a payload emitted with EmitTagged, with its tags.
+	tagged bool
+	tags   []string
+
+	// factory builds the value of an emission of EmitFactory, which has no
+	// payload.
+	factory func() T
+
+	// swap marks a payload emitted with CompareAndEmit, which is delivered
+	// only if the value stored by the signal equals old.
+	swap bool
+	old  T
+
+	// cancel releases the context derived by EmitWithTimeout, if any.
+	cancel context.CancelFunc
+}
+
+// deliver emits the payload on the signal unless its context is already done.
+// It does nothing if p is nil.
+func (p *pendingEmission[T]) deliver(s Signal[T]) {
+	if p == nil {
+		return
+	}
+
+	if p.ctx.Err() == nil {
+		switch {
+		case p.factory != nil:
+			if f, ok := s.(factoryEmitter[T]); ok {
+				_ = f.EmitFactory(p.ctx, p.factory)
+			}
+		case p.swap:
+			if sticky, ok := s.(StickySignal[T]); ok {
+				_, _ = sticky.CompareAndEmit(p.ctx, p.old, p.payload)
+			}
+		case p.tagged:
+			_ = s.EmitTagged(p.ctx, p.payload, p.tags...)
+		default:
+			_ = s.Emit(p.ctx, p.payload)
+		}
+	}
+	p.discard()
+}
+
+// discard releases the resources of the pending emission without delivering
+// it. It does nothing if p is nil.
+func (p *pendingEmission[T]) discard() {
+	if p != nil && p.cancel != nil {
+		p.cancel()
+	}
+}
@@ -0,0 +1,53 @@
+package signals
+
+import "sync"
+
+// progress tracks the listeners invoked by an emission of EmitAndWait, to
+// report the ones that did not return in time.
+type progress struct {
+	mu        sync.Mutex
+	delivered bool
+	keys      []SignalType
+	finished  map[SignalType]bool
+}
+
+// start records the keys of the subscribers the emission is delivered to.
+func (p *progress) start(keys []SignalType) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.delivered = true
+	p.keys = keys
+	p.finished = make(map[SignalType]bool, len(keys))
+}
+
+// finish records that the subscriber with the given key returned.
+func (p *progress) finish(key SignalType) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.finished[key] = true
+}
+
+// interrupted returns the error reported when the context err interrupted the
+// wait for the emission: a *PartialDeliveryError if the emission was
+// delivered, or err itself if it did not reach the listeners yet.
+func (p *progress) interrupted(err error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.delivered {
+		return err
+	}
+
+	partial := &PartialDeliveryError{Err: err}
+	for _, key := range p.keys {
+		if p.finished[key] {
+			partial.Finished++
+		} else {
+			partial.Pending = append(partial.Pending, key)
+		}
+	}
+
+	return partial
+}
@@ -0,0 +1,95 @@
+package signals
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// serialQueue runs the pushed jobs one after the other, in the order they
+// were pushed, on a goroutine that only lives while there are jobs to run.
+type serialQueue struct {
+	mu      sync.Mutex
+	jobs    []func()
+	running bool
+}
+
+// push appends the job to the queue and starts the goroutine running the
+// jobs if it is not running yet.
+func (q *serialQueue) push(job func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs = append(q.jobs, job)
+	if !q.running {
+		q.running = true
+		go q.run()
+	}
+}
+
+// run runs the jobs until the queue is empty.
+func (q *serialQueue) run() {
+	for {
+		q.mu.Lock()
+		if len(q.jobs) == 0 {
+			q.running = false
+			q.jobs = nil
+			q.mu.Unlock()
+			return
+		}
+		job := q.jobs[0]
+		q.jobs[0] = nil
+		q.jobs = q.jobs[1:]
+		q.mu.Unlock()
+
+		job()
+	}
+}
+
+// invocationKey is the context key of the invocation in progress of a
+// listener whose calls are run by a serialQueue.
+type invocationKey struct{}
+
+// invocation is an invocation in progress of a listener run by queue. The
+// context of the invocation carries it, along with the invocations that were
+// in progress when it started, so an emission made with that context can tell
+// whether it would wait for the listener making it.
+type invocation struct {
+	queue  *serialQueue
+	parent *invocation
+	done   atomic.Bool
+}
+
+// enter returns the context of an invocation run by the queue, which is
+// derived from ctx, and the function to call once the invocation returned.
+func enter(ctx context.Context, q *serialQueue) (context.Context, func()) {
+	parent, _ := ctx.Value(invocationKey{}).(*invocation)
+	for parent != nil && parent.done.Load() {
+		parent = parent.parent
+	}
+
+	inv := &invocation{queue: q, parent: parent}
+
+	return context.WithValue(ctx, invocationKey{}, inv), func() {
+		inv.done.Store(true)
+	}
+}
+
+// blocks reports whether a job pushed to the queue with ctx would never run,
+// because ctx is the context of an invocation in progress on the queue.
+func blocks(ctx context.Context, q *serialQueue) bool {
+	inv, _ := ctx.Value(invocationKey{}).(*invocation)
+	for ; inv != nil; inv = inv.parent {
+		if inv.queue == q && !inv.done.Load() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// detach returns a context carrying the values of ctx, without its
+// cancellation nor the invocations in progress it belongs to, for a value
+// delivered later by another goroutine.
+func detach(ctx context.Context) context.Context {
+	return context.WithValue(context.WithoutCancel(ctx), invocationKey{}, (*invocation)(nil))
+}
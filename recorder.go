@@ -0,0 +1,80 @@
+package signals
+
+import (
+	"context"
+	"sync"
+)
+
+// Recorder is a synchronous signal that also records every emitted value, for
+// assertions in tests. It provides all the methods of the Signal it wraps, so
+// listeners can still be added to it. Create it with NewRecorder.
+type Recorder[T any] struct {
+	Signal[T]
+
+	mu     sync.Mutex
+	values []T
+}
+
+// NewRecorder creates a synchronous signal that records the emitted values,
+// which Values returns. A value is recorded when its emission starts, whether
+// or not a listener is invoked with it, so a signal without listeners still
+// records it. The values of EmitBatch are recorded one by one. A value rejected
+// by a validator set with WithValidator, or dropped by a gate set with
+// WithGate, is not recorded.
+//
+// Example:
+//
+//	rec := signals.NewRecorder[Event]()
+//	svc := NewService(rec)
+//	svc.Run(ctx)
+//	assert.Equal(t, []Event{Started, Stopped}, rec.Values())
+func NewRecorder[T any](opts ...SignalOption) *Recorder[T] {
+	r := &Recorder[T]{}
+
+	cfg := newSignalConfig(opts)
+	gate, _ := cfg.gate.(func(ctx context.Context, v T) bool)
+	cfg.gate = func(ctx context.Context, v T) bool {
+		if gate != nil && !gate(ctx, v) {
+			return false
+		}
+		r.mu.Lock()
+		r.values = append(r.values, v)
+		r.mu.Unlock()
+
+		return true
+	}
+	r.Signal = newSyncConfig[T](cfg)
+
+	return r
+}
+
+// Values returns a copy of the values recorded so far, in the order they were
+// emitted.
+func (r *Recorder[T]) Values() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]T(nil), r.values...)
+}
+
+// Reset removes all the listeners like Signal.Reset, and also forgets the
+// recorded values.
+func (r *Recorder[T]) Reset() {
+	r.Signal.Reset()
+
+	r.mu.Lock()
+	r.values = nil
+	r.mu.Unlock()
+}
+
+// ResetAndCancel resets the signal like Signal.ResetAndCancel, and also
+// forgets the recorded values.
+func (r *Recorder[T]) ResetAndCancel(ctx context.Context) error {
+	err := r.Signal.ResetAndCancel(ctx)
+
+	r.mu.Lock()
+	r.values = nil
+	r.mu.Unlock()
+
+	return err
+}
@@ -0,0 +1,55 @@
+package signals
+
+import "sync"
+
+// registry holds the signals registered by name with Register.
+var registry = struct {
+	mu      sync.RWMutex
+	signals map[string]any
+}{signals: make(map[string]any)}
+
+// Register makes the signal available under the given name to the rest of the
+// program through Get, so loosely coupled modules can share a signal by a
+// well-known name instead of passing it around. A signal already registered
+// under the same name is replaced. Register is safe to call from multiple
+// goroutines.
+//
+// Example:
+//
+//	func init() {
+//		signals.Register("user.created", signals.New[User]())
+//	}
+func Register[T any](name string, s Signal[T]) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.signals[name] = s
+}
+
+// Get returns the signal registered under the given name with Register. It
+// returns false if no signal was registered under that name, or if the
+// registered signal does not carry payloads of type T.
+//
+// Example:
+//
+//	if created, ok := signals.Get[User]("user.created"); ok {
+//		created.AddListener(sendWelcomeEmail)
+//	}
+func Get[T any](name string) (Signal[T], bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	s, ok := registry.signals[name].(Signal[T])
+
+	return s, ok
+}
+
+// Unregister removes the signal registered under the given name, if any. The
+// signal itself is not closed, and the listeners it already has keep
+// receiving its emissions.
+func Unregister(name string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	delete(registry.signals, name)
+}
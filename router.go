@@ -0,0 +1,141 @@
+package signals
+
+import (
+	"context"
+	"sync"
+)
+
+// Router dispatches each emitted value to the listeners registered under the
+// key computed from the value, which shards the listeners by a key derived
+// from the values, for instance by tenant. Each key has its own child signal,
+// and the values whose key has no listener go to the default listeners.
+// Create it with NewRouter.
+type Router[K comparable, T any] struct {
+	mu       sync.RWMutex
+	route    func(v T) K
+	children map[K]Signal[T]
+	fallback Signal[T]
+	opts     []SignalOption
+	closed   bool
+}
+
+// NewRouter creates a router. Its child signals, and the signal of its default
+// listeners, are created with New and the given options, so the listeners run
+// asynchronously unless the options say otherwise. The key function must be
+// set with Route before the first emission.
+//
+// Example:
+//
+//	router := signals.NewRouter[string, Order]()
+//	router.Route(func(o Order) string { return o.Tenant })
+//	router.On("acme", handleAcme)
+//	router.OnDefault(handleOthers)
+//	router.Emit(ctx, order) // Invokes handleAcme if order.Tenant is "acme"
+func NewRouter[K comparable, T any](opts ...SignalOption) *Router[K, T] {
+	return &Router[K, T]{
+		children: make(map[K]Signal[T]),
+		fallback: New[T](opts...),
+		opts:     opts,
+	}
+}
+
+// Route sets the function computing the key of each emitted value. It
+// replaces the function set by a previous call, for the emissions that start
+// afterwards.
+func (r *Router[K, T]) Route(route func(v T) K) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.route = route
+}
+
+// On adds a listener invoked with the emitted values whose key is the given
+// one. It accepts the same options and has the same return values as
+// Signal.AddListenerWith.
+//
+// Example:
+//
+//	router.On("acme", func(ctx context.Context, o Order) {
+//		acme.Process(ctx, o)
+//	})
+func (r *Router[K, T]) On(key K, listener SignalListener[T], opts ...ListenerOption) int {
+	return r.Child(key).AddListenerWith(listener, opts...)
+}
+
+// OnDefault adds a listener invoked with the emitted values whose key has no
+// listener. It accepts the same options and has the same return values as
+// Signal.AddListenerWith.
+func (r *Router[K, T]) OnDefault(listener SignalListener[T], opts ...ListenerOption) int {
+	return r.fallback.AddListenerWith(listener, opts...)
+}
+
+// Child returns the signal holding the listeners of the given key, creating
+// it if needed, so its listeners can be removed or inspected. Emitting on the
+// child directly reaches its listeners without going through the key
+// function.
+func (r *Router[K, T]) Child(key K) Signal[T] {
+	r.mu.RLock()
+	child, ok := r.children[key]
+	r.mu.RUnlock()
+	if ok {
+		return child
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if child, ok = r.children[key]; !ok {
+		child = New[T](r.opts...)
+		if r.closed {
+			child.Close()
+		}
+		r.children[key] = child
+	}
+
+	return child
+}
+
+// Default returns the signal holding the default listeners.
+func (r *Router[K, T]) Default() Signal[T] {
+	return r.fallback
+}
+
+// Emit computes the key of the payload with the function set with Route, and
+// emits the payload on the child signal of that key, like Signal.Emit. If no
+// listener is registered under the key, the payload is emitted to the default
+// listeners instead. It returns ErrNoRoute if Route was not called.
+func (r *Router[K, T]) Emit(ctx context.Context, payload T) error {
+	r.mu.RLock()
+	route := r.route
+	r.mu.RUnlock()
+	if route == nil {
+		return ErrNoRoute
+	}
+
+	return r.target(route(payload)).Emit(ctx, payload)
+}
+
+// target returns the signal receiving the values of the given key.
+func (r *Router[K, T]) target(key K) Signal[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if child, ok := r.children[key]; ok && !child.IsEmpty() {
+		return child
+	}
+
+	return r.fallback
+}
+
+// Close closes the child signals and the signal of the default listeners,
+// like Signal.Close. The emissions that follow return ErrSignalClosed,
+// including those to the keys that get their first listener afterwards.
+func (r *Router[K, T]) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	for _, child := range r.children {
+		child.Close()
+	}
+	r.fallback.Close()
+}
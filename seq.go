@@ -0,0 +1,43 @@
+package signals
+
+import "context"
+
+// seqKey is the context key of the sequence number of an emission.
+type seqKey struct{}
+
+// withSeq returns a copy of ctx carrying the sequence number of an emission.
+func withSeq(ctx context.Context, seq uint64) context.Context {
+	return context.WithValue(ctx, seqKey{}, seq)
+}
+
+// SeqFrom returns the sequence number of the emission a listener is invoked
+// for, and whether ctx carries one. Each signal numbers its emissions from 1,
+// in the order they are dispatched to the listeners, so the listeners can
+// detect the emissions they missed or received out of order, for instance on
+// an asynchronous signal emitted from multiple goroutines. The numbering
+// starts over after Reset, and a clone of a signal numbers its emissions on
+// its own.
+//
+// An emission gets its number when it reaches the listeners: the values
+// rejected by a gate, dropped or coalesced take no number, and a value queued
+// by a signal created with WithBuffer, or held by a paused signal, is numbered
+// when it is delivered. The values of EmitBatch share the number of their
+// emission. A listener skipping some emissions, because of its filter or its
+// tags, sees gaps in the numbers. The value replayed to a listener added to a
+// signal created with WithReplay has no number.
+//
+// Example:
+//
+//	var last uint64
+//	signal.AddListener(func(ctx context.Context, event Event) {
+//		seq, _ := signals.SeqFrom(ctx)
+//		if seq > last+1 {
+//			log.Printf("missed %d events", seq-last-1)
+//		}
+//		last = seq
+//	})
+func SeqFrom(ctx context.Context) (uint64, bool) {
+	seq, ok := ctx.Value(seqKey{}).(uint64)
+
+	return seq, ok
+}
@@ -12,3 +12,38 @@ import "context"
 //
 // The function does not return any value.
 type SignalListener[T any] func(context.Context, T)
+
+// ResultListener is a listener that reports the outcome of its work. It takes
+// the same parameters as SignalListener and returns an error if the payload
+// could not be processed. It is used by the signals created with NewResult.
+type ResultListener[T any] func(context.Context, T) error
+
+// StoppableListener is a listener of a StoppableSignal. It returns false to
+// stop the propagation of the emission, so the listeners that come after it
+// are not invoked.
+type StoppableListener[T any] func(context.Context, T) bool
+
+// AbortableListener is a listener of a StoppableSignal that can abort the
+// whole emission. It returns true to stop the propagation like a
+// StoppableListener, or a non-nil error to abort the emission: the listeners
+// that come after it are not invoked, and Emit returns an *AbortError holding
+// the error.
+type AbortableListener[T any] func(context.Context, T) (stop bool, err error)
+
+// Handler is implemented by the objects that can be added to a signal with
+// AddHandler, such as stateful services, as an alternative to a listener
+// function.
+type Handler[T any] interface {
+	OnSignal(ctx context.Context, payload T)
+}
+
+// BatchListener is a listener that receives several values at once. It is
+// added with AddBatchListener and receives the values emitted together with
+// EmitBatch in a single invocation.
+type BatchListener[T any] func(context.Context, []T)
+
+// Middleware wraps the invocations of the listeners of a signal. It receives
+// the next handler in the chain and returns the handler to invoke instead. A
+// middleware can run code around the call to next, pass a different context
+// to it, or skip the listener by not calling next at all.
+type Middleware[T any] func(next SignalListener[T]) SignalListener[T]
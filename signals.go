@@ -1,6 +1,9 @@
 package signals
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Signal is the interface that represents a signal that can be subscribed to
 // emitting a payload of type T.
@@ -11,6 +14,10 @@ type Signal[T any] interface {
 	// must respect it. If the signal is async (default), the listeners are called
 	// in a separate goroutine.
 	//
+	// The listeners are taken from a snapshot made when the emission starts,
+	// so the listeners added or removed during the emission, even by a
+	// listener, only take effect from the next emission.
+	//
 	// Example:
 	//	signal := signals.New[int]()
 	//	signal.AddListener(func(ctx context.Context, payload int) {
@@ -20,23 +27,427 @@ type Signal[T any] interface {
 	//	signal.Emit(context.Background(), 42)
 	Emit(ctx context.Context, payload T) error
 
+	// EmitWithTimeout emits the payload like Emit, with a context derived from
+	// ctx that is cancelled after the given timeout.
+	//
+	// The derived context is always cancelled before EmitWithTimeout returns,
+	// once the listeners have returned.
+	//
+	// Example:
+	//	signal := signals.New[int]()
+	//	err := signal.EmitWithTimeout(context.Background(), 42, time.Second)
+	EmitWithTimeout(ctx context.Context, payload T, timeout time.Duration) error
+
+	// MustEmit emits the payload like Emit, and panics with the error of Emit
+	// if there is one.
+	//
+	// It is meant for the call sites where an error is a programming mistake,
+	// such as emitting on a closed signal.
+	//
+	// Example:
+	//	signal.MustEmit(ctx, 42)
+	MustEmit(ctx context.Context, payload T)
+
+	// EmitBatch emits several values in a single emission.
+	//
+	// Each listener is invoked once for the whole batch: a listener added with
+	// AddBatchListener receives the values in one call, and any other listener
+	// is invoked with each value in turn, in the order of the slice. A
+	// synchronous signal invokes the listeners one after the other, so a
+	// listener receives all the values before the next listener receives any.
+	// An asynchronous signal runs each listener in its own goroutine.
+	//
+	// Example:
+	//	err := signal.EmitBatch(ctx, []int{1, 2, 3})
+	EmitBatch(ctx context.Context, values []T) error
+
+	// EmitMany emits the values in a single emission like EmitBatch, for the
+	// values passed as separate arguments.
+	//
+	// Example:
+	//	err := signal.EmitMany(ctx, 1, 2, 3)
+	EmitMany(ctx context.Context, values ...T) error
+
+	// EmitTagged emits the payload like Emit, but only invokes the listeners
+	// added with WithTags having at least one of the given tags.
+	//
+	// The listeners without tags are skipped. A tagged emission is not
+	// recorded by WithReplay.
+	//
+	// Example:
+	//	signal.AddListener(audit, signals.WithTags("audit"))
+	//	signal.EmitTagged(ctx, event, "audit")
+	EmitTagged(ctx context.Context, payload T, tags ...string) error
+
+	// EmitAndWait emits the payload like Emit and blocks until all the
+	// listeners have returned, or until the context is done.
+	//
+	// If the context is done first, the listeners that are still running keep
+	// running in the background, and EmitAndWait returns a
+	// *PartialDeliveryError wrapping the context error, with the keys of the
+	// listeners that did not return, or the context error itself if the value
+	// did not reach the listeners yet. For a signal created with WithBuffer,
+	// or for a paused signal, EmitAndWait returns once the value was queued.
+	//
+	// Example:
+	//	signal := signals.New[int]()
+	//	err := signal.EmitAndWait(ctx, 42)
+	EmitAndWait(ctx context.Context, payload T) error
+
+	// EmitWithAck emits the payload like Emit, without waiting for the
+	// listeners, and returns a channel receiving an Ack from each listener as
+	// soon as it returns. The channel is closed once all the listeners
+	// returned, or once the context is done.
+	//
+	// Example:
+	//	acks, err := signal.EmitWithAck(ctx, job)
+	//	for ack := range acks {
+	//		log.Printf("listener %d done: %v", ack.Key, ack.Err)
+	//	}
+	EmitWithAck(ctx context.Context, payload T) (<-chan Ack, error)
+
+	// EmitWithPriority emits the payload like Emit, with the given priority
+	// in the buffer of a signal created with WithBuffer, whose values are
+	// delivered by descending priority. Without a buffer, it is the same as
+	// Emit.
+	//
+	// Example:
+	//	signal.EmitWithPriority(ctx, cancelAll, 10)
+	EmitWithPriority(ctx context.Context, payload T, priority int) error
+
 	// AddListener adds a listener to the signal.
 	//
 	// The listener will be called whenever the signal is emitted. It returns the
-	// number of subscribers after the listener was added. It accepts an optional key
-	// that can be used to remove the listener later or to check if the listener
-	// was already added. It returns -1 if the listener with the same key
-	// was already added to the signal.
+	// number of subscribers after the listener was added. It accepts an
+	// optional key that can be used to remove the listener later or to check
+	// if the listener was already added. It returns -1 if the listener with
+	// the same key was already added to the signal, or ListenerLimitReached if
+	// the limit set with WithMaxListeners is reached.
 	//
 	// Example:
 	//	signal := signals.NewSync[int]()
 	//	count := signal.AddListener(func(ctx context.Context, payload int) {
 	//		// Listener implementation
 	//		// ...
-	//	})
+	//	}, 1)
 	//	fmt.Println("Number of subscribers after adding listener:", count)
 	AddListener(handler SignalListener[T], key ...SignalType) int
 
+	// AddListenerWith adds a listener like AddListener, configured by
+	// listener options, such as a SignalType key or WithPriority to invoke
+	// the listener before listeners of lower priority.
+	//
+	// Example:
+	//	signal := signals.NewSync[int]()
+	//	signal.AddListenerWith(func(ctx context.Context, payload int) {
+	//		// Listener implementation
+	//		// ...
+	//	}, signals.SignalType(1), signals.WithPriority(10))
+	AddListenerWith(handler SignalListener[T], opts ...ListenerOption) int
+
+	// AddListenerWithID adds a listener like AddListener, and also returns the
+	// ID of the listener.
+	//
+	// The ID can be passed to RemoveListener to remove the listener even if it
+	// was added without a key. It returns -1 as count, and does not add the
+	// listener, if a listener with the same key was already added.
+	//
+	// Example:
+	//	id, _ := signal.AddListenerWithID(func(ctx context.Context, payload int) {
+	//		// Listener implementation
+	//		// ...
+	//	})
+	//	signal.RemoveListener(id)
+	AddListenerWithID(handler SignalListener[T], opts ...ListenerOption) (id ListenerID, count int)
+
+	// On adds a listener like AddListener and returns a function that removes
+	// exactly that listener.
+	//
+	// The returned function is safe to call several times, or after the
+	// listener was removed by other means such as Reset; it never removes
+	// another listener added later with the same key. If a listener with the
+	// same key was already added, the listener is not added and the returned
+	// function does nothing.
+	//
+	// Example:
+	//	off := signal.On(func(ctx context.Context, payload int) {
+	//		// Listener implementation
+	//		// ...
+	//	})
+	//	defer off()
+	On(handler SignalListener[T], opts ...ListenerOption) (off func())
+
+	// AddBatchListener adds a listener that receives all the values emitted
+	// with EmitBatch in a single invocation.
+	//
+	// A value emitted with Emit is passed as a batch of one value. It accepts
+	// the same options and has the same return values as AddListenerWith. The
+	// middlewares added with Use do not wrap the invocations of a batch.
+	//
+	// Example:
+	//	signal.AddBatchListener(func(ctx context.Context, events []Event) {
+	//		store.InsertAll(ctx, events)
+	//	})
+	AddBatchListener(handler BatchListener[T], opts ...ListenerOption) int
+
+	// AddListenerDelta adds a listener invoked with the previously emitted
+	// value along with each emitted value. It receives false, and the zero
+	// value as previous value, when there is none, such as for the first
+	// emission.
+	//
+	// Example:
+	//	signal.AddListenerDelta(func(ctx context.Context, prev, curr Status, ok bool) {
+	//		if ok && prev != curr {
+	//			log.Printf("status changed from %v to %v", prev, curr)
+	//		}
+	//	})
+	AddListenerDelta(listener DeltaListener[T], opts ...ListenerOption) int
+
+	// AddListenerCtx adds a listener like AddListener that is removed
+	// automatically once the context is done.
+	//
+	// No goroutine waits for the context, and the removal is unregistered if
+	// the listener is removed first. If the context is already done, the
+	// listener is removed right away. The listener is never invoked once the
+	// context is done, even by an emission that started before. It receives
+	// the context of the emission, whose cancellation does not remove it.
+	//
+	// Example:
+	//	changed.AddListenerCtx(ctx, w.render)
+	AddListenerCtx(ctx context.Context, handler SignalListener[T], opts ...ListenerOption) int
+
+	// AddHandler adds the OnSignal method of the handler as a listener of the
+	// signal.
+	//
+	// The handler itself identifies the listener, so it can be removed later
+	// with RemoveHandler. It accepts the same options as AddListenerWith. It
+	// returns -1 if the handler, or a listener with the same key, was already
+	// added, or if the handler is not comparable.
+	//
+	// Example:
+	//	signal.AddHandler(mailer)
+	//	defer signal.RemoveHandler(mailer)
+	AddHandler(h Handler[T], opts ...ListenerOption) int
+
+	// RemoveHandler removes the handler added with AddHandler.
+	//
+	// It returns the number of subscribers after the handler was removed, or
+	// -1 if the handler was not found.
+	RemoveHandler(h Handler[T]) int
+
+	// ReplaceListener swaps the function of the listener with the given key
+	// for listener, and returns true. If no listener has the key, the listener
+	// is added with the key and the given options, and ReplaceListener returns
+	// false.
+	//
+	// The replaced listener keeps its position and its options. The
+	// replacement is atomic with respect to the emissions, which invoke either
+	// the old function or the new one.
+	//
+	// Example:
+	//	signal.ReplaceListener(reloaded.Handle, signals.SignalType(1))
+	ReplaceListener(handler SignalListener[T], key SignalType, opts ...ListenerOption) bool
+
+	// AddListenerOnce adds a listener to the signal that is invoked at most once.
+	//
+	// The listener is removed from the signal right before its first
+	// invocation, so it never runs twice even when the signal is emitted
+	// concurrently. It accepts the same options as AddListenerWith and has the same
+	// return values.
+	//
+	// Example:
+	//	signal := signals.New[int]()
+	//	signal.AddListenerOnce(func(ctx context.Context, payload int) {
+	//		// Called for the first emission only
+	//		// ...
+	//	})
+	AddListenerOnce(handler SignalListener[T], opts ...ListenerOption) int
+
+	// AddListenerFiltered adds a listener that is only invoked for the payloads
+	// accepted by the filter.
+	//
+	// The filter is evaluated before the listener is invoked, and, for an
+	// asynchronous signal, before a goroutine is started for it. It accepts the
+	// same options and has the same return values as AddListenerWith.
+	//
+	// Example:
+	//	signal := signals.New[Event]()
+	//	signal.AddListenerFiltered(func(ctx context.Context, payload Event) {
+	//		// Only called for the "created" events
+	//	}, func(payload Event) bool {
+	//		return payload.Type == "created"
+	//	})
+	AddListenerFiltered(handler SignalListener[T], filter func(T) bool, opts ...ListenerOption) int
+
+	// AddCloseListener adds a function invoked once when the signal is
+	// closed, after the emissions in progress finished.
+	//
+	// The close listeners are invoked in the order they were added, and Wait
+	// returns once they all returned. It returns false if the signal is
+	// already closed.
+	//
+	// Example:
+	//	signal.AddCloseListener(func(ctx context.Context) {
+	//		writer.Flush()
+	//	})
+	AddCloseListener(listener func(ctx context.Context)) bool
+
+	// RemoveListenerAndWait removes the listener with the given key like
+	// RemoveListener, and then blocks until its invocations in progress have
+	// returned, or until the context is done.
+	//
+	// The emissions in progress that did not invoke the listener yet skip it.
+	// It returns the same count as RemoveListener, and the context error if
+	// the context is done first.
+	//
+	// Example:
+	//	if _, err := signal.RemoveListenerAndWait(ctx, key); err == nil {
+	//		conn.Close()
+	//	}
+	RemoveListenerAndWait(ctx context.Context, key SignalType) (int, error)
+
+	// CancelListener cancels the context of the invocations in progress of the
+	// listener with the given key, without removing the listener, and returns
+	// how many were cancelled.
+	//
+	// The cancellation is cooperative: the listener must watch ctx.Done().
+	//
+	// Example:
+	//	signal.CancelListener(workerKey)
+	CancelListener(key SignalType) int
+
+	// RemoveGroup removes all the listeners added with the given tag, and
+	// returns how many were removed.
+	//
+	// A listener belonging to several groups is removed as soon as one of its
+	// groups is removed.
+	//
+	// Example:
+	//	removed := signal.RemoveGroup("audit")
+	RemoveGroup(tag string) int
+
+	// Use adds middlewares that wrap every invocation of the listeners of the
+	// signal.
+	//
+	// The middlewares apply in the order they were added: the first one is the
+	// outermost and runs first. A middleware can change the context passed to
+	// the listener, or skip the listener by not calling next.
+	//
+	// Example:
+	//	signal := signals.New[int]()
+	//	signal.Use(func(next signals.SignalListener[int]) signals.SignalListener[int] {
+	//		return func(ctx context.Context, payload int) {
+	//			log.Println("invoking listener")
+	//			next(ctx, payload)
+	//		}
+	//	})
+	Use(mws ...Middleware[T])
+
+	// HasListener reports whether a listener with the given key is subscribed
+	// to the signal.
+	//
+	// Example:
+	//	signal := signals.New[int]()
+	//	if !signal.HasListener(signals.SignalType(1)) {
+	//		signal.AddListener(listener, signals.SignalType(1))
+	//	}
+	HasListener(key SignalType) bool
+
+	// Keys returns the keys of the listeners subscribed to the signal.
+	//
+	// The keys are listed in the order the listeners are invoked. Listeners
+	// added without a key, and those added with a key generated by the package,
+	// are not included. The returned slice is a copy that the caller is free to
+	// modify.
+	//
+	// Example:
+	//	signal := signals.New[int]()
+	//	signal.AddListener(listener, signals.SignalType(1))
+	//	signal.AddListener(listener)
+	//	fmt.Println(signal.Keys()) // [1]
+	Keys() []SignalType
+
+	// Subscribe returns a channel that receives every value emitted by the
+	// signal until the context is cancelled.
+	//
+	// When the context is cancelled, the underlying listener is removed from the
+	// signal and the channel is closed. The channel has a buffer of the given
+	// size. If the buffer is full, or if the channel is unbuffered and the
+	// consumer is not ready to receive, the emitted value is dropped for this
+	// subscriber, so a slow consumer never blocks the emission. The channel is
+	// closed right away if the signal refuses the listener.
+	//
+	// Example:
+	//	signal := signals.New[int]()
+	//	for v := range signal.Subscribe(ctx, 16) {
+	//		fmt.Println("Received:", v)
+	//	}
+	Subscribe(ctx context.Context, bufferSize int) <-chan T
+
+	// SubscribeWithDrops returns a channel that receives the emitted values
+	// like Subscribe, along with a channel notified when values are dropped
+	// because the consumer is not keeping up.
+	//
+	// The notifications are coalesced. Both channels are closed once the
+	// context is cancelled.
+	//
+	// Example:
+	//	values, dropped := signal.SubscribeWithDrops(ctx, 16)
+	SubscribeWithDrops(ctx context.Context, bufferSize int) (values <-chan T, dropped <-chan struct{})
+
+	// SubscribeWithHistory returns a channel that first receives the values
+	// of the history of a signal created with WithHistory, and then every
+	// value emitted afterwards like Subscribe. A value emitted during the
+	// subscription is received exactly once.
+	//
+	// Example:
+	//	for e := range signal.SubscribeWithHistory(ctx, 16) {
+	//		project(e)
+	//	}
+	SubscribeWithHistory(ctx context.Context, bufferSize int) <-chan T
+
+	// WaitFor blocks until the signal emits a value accepted by the
+	// predicate, and returns that value.
+	//
+	// A nil predicate accepts any value. The temporary listener is removed
+	// before WaitFor returns. If the context is done first, WaitFor returns the
+	// zero value and the context error, and if the signal refuses the
+	// temporary listener, ErrListenerRefused.
+	//
+	// Example:
+	//	status, err := signal.WaitFor(ctx, func(s Status) bool {
+	//		return s == Ready
+	//	})
+	WaitFor(ctx context.Context, pred func(T) bool) (T, error)
+
+	// Pipe forwards every value emitted by the signal to the destination signal.
+	//
+	// The values are forwarded with the context of the emission. The optional
+	// onError callbacks are called with the errors returned by the Emit method
+	// of the destination. The returned stop function removes the forwarding
+	// listener; it is idempotent. Pipe returns nil if the signal refuses the
+	// listener.
+	//
+	// Example:
+	//	stop := audit.Pipe(archive, func(err error) {
+	//		log.Println("archive failed:", err)
+	//	})
+	//	defer stop()
+	Pipe(dst Signal[T], onError ...func(error)) (stop func())
+
+	// Tap calls sink with every value emitted by the signal, converted to
+	// any, and with the context of the emission.
+	//
+	// The other listeners still receive the typed value. Converting a value to
+	// any may allocate. The returned stop function removes the sink; it is
+	// idempotent. Tap returns nil if the signal refuses the sink.
+	//
+	// Example:
+	//	defer orders.Tap(func(ctx context.Context, value any) {
+	//		log.Printf("emitted %T: %v", value, value)
+	//	})()
+	Tap(sink func(ctx context.Context, value any)) (stop func())
+
 	// RemoveListener removes a listener from the signal.
 	//
 	// It returns the number of subscribers after the listener was removed.
@@ -52,6 +463,18 @@ type Signal[T any] interface {
 	//	fmt.Println("Number of subscribers after removing listener:", count)
 	RemoveListener(key SignalType) int
 
+	// Clone returns a new signal of the same kind, with the same options,
+	// listeners and middlewares.
+	//
+	// The listener functions are shared: only the registration table is
+	// copied, so adding or removing listeners, resetting, pausing or closing
+	// one signal does not affect the other.
+	//
+	// Example:
+	//	scoped := base.Clone()
+	//	scoped.AddListener(traceRequest) // base is not affected
+	Clone() Signal[T]
+
 	// Reset resets the signal by removing all subscribers from the signal,
 	// effectively clearing the list of subscribers.
 	//
@@ -68,6 +491,98 @@ type Signal[T any] interface {
 	//	fmt.Println("Number of subscribers after resetting:", signal.Len())
 	Reset()
 
+	// ResetAndCancel resets the signal like Reset, cancels the context of the
+	// invocations of the removed listeners in progress, and waits for them to
+	// return or for the context to be done.
+	//
+	// Unlike Reset, the emissions in progress no longer invoke the removed
+	// listeners.
+	//
+	// Example:
+	//	err := signal.ResetAndCancel(ctx)
+	ResetAndCancel(ctx context.Context) error
+
+	// Close marks the signal as closed.
+	//
+	// Once closed, Emit returns ErrSignalClosed without invoking any listener,
+	// while the emissions already in progress complete normally. Close is
+	// idempotent, and a closed signal cannot be re-opened, not even by Reset.
+	//
+	// Example:
+	//	signal := signals.New[int]()
+	//	// ...
+	//	signal.Close()
+	//	err := signal.Wait(shutdownCtx)
+	Close()
+
+	// Wait blocks until all the emissions in progress have finished.
+	//
+	// For an asynchronous signal this includes the listener goroutines. If the
+	// context is done first, Wait returns the context error.
+	Wait(ctx context.Context) error
+
+	// History returns the last values emitted by a signal created with
+	// WithHistory, oldest first.
+	//
+	// It returns an empty slice for a signal without history. The returned
+	// slice is a copy that the caller is free to modify.
+	//
+	// Example:
+	//	signal := signals.NewSync[int](signals.WithHistory(3))
+	//	// ...
+	//	fmt.Println(signal.History())
+	History() []T
+
+	// HistorySince returns the values of the history emitted after t, oldest
+	// first.
+	//
+	// The oldest values are lost once the history is full, so fewer values
+	// than were emitted since t may be returned.
+	//
+	// Example:
+	//	missed := signal.HistorySince(lastSeen)
+	HistorySince(t time.Time) []T
+
+	// Pause stops the delivery of the emitted values until Resume is called.
+	//
+	// The listeners stay subscribed, so Len and HasListener are not affected.
+	// What Emit does while the signal is paused depends on the policy set with
+	// WithPausePolicy: by default the values are dropped and Emit returns
+	// ErrPaused. Pause is idempotent.
+	//
+	// Example:
+	//	signal.Pause()
+	//	defer signal.Resume()
+	//	// maintenance
+	Pause()
+
+	// Resume restarts the delivery of the emitted values after Pause.
+	//
+	// With the PauseReplay policy, the values emitted while the signal was
+	// paused are delivered first, in the order they were emitted, before Resume
+	// returns. Resume does nothing if the signal is not paused.
+	Resume()
+
+	// IsPaused reports whether the signal was paused with Pause and not
+	// resumed yet.
+	IsPaused() bool
+
+	// QueueDepth returns the number of values waiting to be delivered by a
+	// signal created with WithBuffer.
+	//
+	// It returns 0 for a signal without a buffer.
+	QueueDepth() int
+
+	// Drain removes the values waiting to be delivered by a signal created
+	// with WithBuffer, or kept by a paused signal, and returns them in the
+	// order they would have been delivered. The drained values are never
+	// delivered.
+	//
+	// Example:
+	//	signal.Close()
+	//	pending := signal.Drain()
+	Drain() []T
+
 	// Len returns the number of listeners subscribed to the signal.
 	//
 	// This can be used to check how many listeners are currently waiting for a signal.
@@ -96,4 +611,68 @@ type Signal[T any] interface {
 	//	})
 	//	fmt.Println("Is signal empty?", signal.IsEmpty()) // Should print false
 	IsEmpty() bool
+
+	// Stats returns a snapshot of the counters of the signal: its listeners,
+	// its emissions, the invocations running, and the values dropped and the
+	// invocations that failed.
+	//
+	// Reset sets the cumulative counters back to zero.
+	//
+	// Example:
+	//	stats := signal.Stats()
+	Stats() Stats
+}
+
+// StickySignal is a Signal that can update the value it stores atomically.
+// The signals created by NewSticky, or with the WithReplay option, store their
+// last value. NewSticky returns a StickySignal, and the signals of this
+// package, including the wrappers returned by Debounce and Throttle,
+// implement it.
+type StickySignal[T any] interface {
+	Signal[T]
+
+	// CompareAndEmit emits the new value like Emit if the value stored by a
+	// signal created with WithReplay or NewSticky equals old, and returns
+	// true.
+	//
+	// The comparison and the update of the stored value are atomic. If the
+	// comparison fails, it returns false without emitting. It returns
+	// ErrNotSticky if the signal does not store its last value.
+	//
+	// Example:
+	//	state := signals.NewSticky[State]()
+	//	ok, err := state.CompareAndEmit(ctx, Idle, Running)
+	CompareAndEmit(ctx context.Context, old, new T) (bool, error)
+}
+
+// factoryEmitter, countingEmitter and tryEmitter are implemented by the
+// signals of this package. The wrappers of a Signal use them to reach the
+// EmitFactory, EmitN and TryEmit methods of the signal they wrap.
+type (
+	factoryEmitter[T any] interface {
+		EmitFactory(ctx context.Context, factory func() T) error
+	}
+	countingEmitter[T any] interface {
+		EmitN(ctx context.Context, payload T) (int, error)
+	}
+	tryEmitter[T any] interface {
+		TryEmit(ctx context.Context, payload T) (bool, error)
+	}
+)
+
+// unheardReporter is implemented by the signals of this package. The wrappers
+// of a Signal use it to report the error of an emission that reaches no
+// listener of the signal they wrap, as the signal itself would.
+type unheardReporter interface {
+	noListeners() error
+}
+
+// noListeners returns the error of an emission that reaches no listener of s,
+// or nil if s does not tell.
+func noListeners[T any](s Signal[T]) error {
+	if r, ok := s.(unheardReporter); ok {
+		return r.noListeners()
+	}
+
+	return nil
 }
@@ -2,6 +2,7 @@ package signals
 
 import (
 	"context"
+	"errors"
 	"sync"
 )
 
@@ -15,7 +16,13 @@ import (
 type AsyncSignal[T any] struct {
 	BaseSignal[T]
 
-	mu sync.RWMutex
+	// slots bounds the number of running listener invocations when the signal
+	// is created with WithMaxConcurrency. It is nil if there is no limit.
+	slots chan struct{}
+
+	// order makes the emissions of a signal created with WithOrderedDelivery
+	// enqueue their invocations to all the listeners atomically.
+	order sync.Mutex
 }
 
 // Emit notifies all subscribers of the signal and passes the payload in a
@@ -29,6 +36,39 @@ type AsyncSignal[T any] struct {
 // to wait for the listeners to finish, you can call the Emit method. Also,
 // you must know that Emit does not guarantee the type safety of the emitted value.
 //
+// If the signal was created with WithMaxConcurrency, Emit starts a listener
+// only when fewer than the configured number of invocations are running, and
+// it returns the context error if the context is cancelled while waiting.
+//
+// If the signal was closed, Emit returns ErrSignalClosed.
+//
+// Concurrent calls to Emit do not wait for each other: each emission starts
+// the goroutines of its listeners right away, so a listener may run for
+// several emissions at once. Use WithOrderedDelivery to invoke each listener
+// for one emission at a time.
+//
+// The listeners are taken from a snapshot made when the emission starts. A
+// listener added during the emission, for instance by another listener, is
+// first invoked by the next emission, and a listener removed during the
+// emission is still invoked by this one.
+//
+// A panic of a listener is recovered, so it does not terminate the program.
+// The recovered panics are returned as *PanicError values joined with
+// errors.Join, in the order of the listeners.
+//
+// If the signal was created with WithOrderedDelivery, the invocations are
+// queued per listener instead, so each listener receives the values in the
+// order the emissions started.
+//
+// The goroutines of the listeners are started in descending priority order
+// (see WithPriority), so a listener of higher priority is dispatched before
+// the listeners of lower priority. Since the listeners then run concurrently,
+// this is best-effort: the Go scheduler may run a goroutine started later
+// first, and the listeners finish in any order. With WithMaxConcurrency, a
+// listener only starts once the listeners of higher priority took their slot,
+// so with a limit of one the listeners run one after the other in priority
+// order.
+//
 // Example:
 //
 //	signal := signals.New[string]()
@@ -39,24 +79,158 @@ type AsyncSignal[T any] struct {
 //
 //	signal.Emit(context.Background(), "Hello, world!")
 func (s *AsyncSignal[T]) Emit(ctx context.Context, payload T) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	return s.emit(ctx, s, payload)
+}
 
+// dispatch calls each subscriber in a separate goroutine and waits for all of
+// them to return.
+func (s *AsyncSignal[T]) dispatch(ctx context.Context, subscribers []*keyedListener[T], e emission[T]) error {
+	if s.config.ordered {
+		return s.dispatchOrdered(ctx, subscribers, e, false)
+	}
+
+	return s.dispatchConcurrent(ctx, subscribers, e, 0)
+}
+
+// reserve takes, without blocking, a concurrency slot for each subscriber
+// accepting the emission, and returns the function dispatching the emission
+// with them. It returns nil if a slot is not available.
+func (s *AsyncSignal[T]) reserve(subscribers []*keyedListener[T], e emission[T]) func(context.Context) error {
+	reserved := 0
+	if s.slots != nil {
+		for _, sub := range subscribers {
+			if !e.accepts(sub) {
+				continue
+			}
+
+			select {
+			case s.slots <- struct{}{}:
+				reserved++
+			default:
+				for ; reserved > 0; reserved-- {
+					<-s.slots
+				}
+				return nil
+			}
+		}
+	}
+
+	return func(ctx context.Context) error {
+		if s.config.ordered {
+			return s.dispatchOrdered(ctx, subscribers, e, s.slots != nil)
+		}
+
+		return s.dispatchConcurrent(ctx, subscribers, e, reserved)
+	}
+}
+
+// dispatchConcurrent starts a goroutine for each subscriber and waits for all
+// of them to return. The first reserved invocations use the concurrency slots
+// already taken by reserve.
+func (s *AsyncSignal[T]) dispatchConcurrent(ctx context.Context, subscribers []*keyedListener[T], e emission[T], reserved int) error {
 	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// The slots reserved for the invocations that were not started are
+	// released when the emission stops early.
+	defer func() {
+		for ; reserved > 0; reserved-- {
+			<-s.slots
+		}
+	}()
+
+	errs := make([]error, len(subscribers))
+	for i, sub := range subscribers {
+		if !e.accepts(sub) {
+			s.skipped(sub)
+			continue
+		}
 
-	for _, sub := range s.subscribers {
-		wg.Add(1)
 		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		go func(listener func(context.Context, T)) {
+		queued := s.queued()
+		if reserved > 0 {
+			reserved--
+		} else if s.slots != nil {
+			select {
+			case s.slots <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		wg.Add(1)
+		go func(i int, sub *keyedListener[T]) {
+			defer wg.Done()
+			if s.slots != nil {
+				defer func() { <-s.slots }()
+			}
+			s.started(sub, queued)
+			errs[i] = s.deliverTo(ctx, sub, e)
+		}(i, sub)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// dispatchOrdered pushes an invocation to the queue of each subscriber and
+// waits for all of them to return. If reserved is true, the concurrency slots
+// of the invocations were already taken by reserve. It returns
+// ErrReentrantEmit, without invoking any subscriber, if one of them is the
+// listener emitting the value.
+func (s *AsyncSignal[T]) dispatchOrdered(ctx context.Context, subscribers []*keyedListener[T], e emission[T], reserved bool) error {
+	for _, sub := range subscribers {
+		if e.accepts(sub) && blocks(ctx, sub.queue) {
+			if reserved {
+				for _, sub := range subscribers {
+					if e.accepts(sub) {
+						<-s.slots
+					}
+				}
+			}
+			return ErrReentrantEmit
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	errs := make([]error, len(subscribers))
+	s.order.Lock()
+	for i, sub := range subscribers {
+		if !e.accepts(sub) {
+			s.skipped(sub)
+			continue
+		}
+
+		i, sub := i, sub
+		queued := s.queued()
+		wg.Add(1)
+		sub.queue.push(func() {
 			defer wg.Done()
-			listener(ctx, payload)
-		}(sub.listener)
+			ctx, leave := enter(ctx, sub.queue)
+			defer leave()
+			if s.slots != nil {
+				if !reserved {
+					select {
+					case s.slots <- struct{}{}:
+					case <-ctx.Done():
+						errs[i] = ctx.Err()
+						return
+					}
+				}
+				defer func() { <-s.slots }()
+			}
+			s.started(sub, queued)
+			errs[i] = s.deliverTo(ctx, sub, e)
+		})
 	}
+	s.order.Unlock()
 
 	wg.Wait()
 
-	return nil
+	return errors.Join(errs...)
 }
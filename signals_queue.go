@@ -0,0 +1,220 @@
+package signals
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// SelectionPolicy decides which listener of a QueueSignal receives an
+// emission. It is set with WithSelection.
+type SelectionPolicy int
+
+const (
+	// RoundRobin gives the emissions to the listeners in turn, in the order of
+	// their priority. It is the default policy.
+	RoundRobin SelectionPolicy = iota
+
+	// Random gives each emission to a listener chosen at random.
+	Random
+
+	// LeastBusy gives each emission to the listener with the fewest
+	// invocations in progress, the one of highest priority in case of a tie.
+	LeastBusy
+)
+
+// QueueSignal is a signal that distributes the emitted values among its
+// listeners instead of broadcasting them: each emission is delivered to
+// exactly one listener, chosen by the policy set with WithSelection, which
+// turns the signal into a simple work dispatcher. The listener is invoked in
+// the goroutine of Emit, so the emissions made concurrently run concurrently,
+// each on its own listener. Create it with NewQueue.
+type QueueSignal[T any] struct {
+	BaseSignal[T]
+
+	// next is the turn of the round-robin policy.
+	next atomic.Uint64
+
+	// backlog holds the values emitted while no listener accepted them, when
+	// the signal is created with WithBacklog.
+	mu      sync.Mutex
+	backlog []bufferedEmission[T]
+}
+
+// NewQueue creates a new signal that delivers each emitted value to a single
+// listener. Without a listener accepting the value, Emit returns
+// ErrNoListeners, or keeps the value until a listener is added if the signal
+// is created with WithBacklog.
+//
+// Example:
+//
+//	jobs := signals.NewQueue[Job](signals.WithSelection(signals.LeastBusy))
+//	for i := 0; i < workers; i++ {
+//		jobs.AddListener(func(ctx context.Context, job Job) {
+//			job.Run(ctx)
+//		})
+//	}
+//	jobs.Emit(context.Background(), job) // Runs on one of the workers
+func NewQueue[T any](opts ...SignalOption) *QueueSignal[T] {
+	return newQueueConfig[T](newSignalConfig(opts))
+}
+
+// newQueueConfig creates a new QueueSignal with the given configuration.
+func newQueueConfig[T any](cfg signalConfig) *QueueSignal[T] {
+	s := &QueueSignal[T]{}
+	s.init(cfg, s)
+
+	return s
+}
+
+// Emit delivers the payload to one listener, chosen among the listeners
+// accepting it by the selection policy of the signal, and returns its error.
+// The filters and tags of the listeners decide which ones accept the payload,
+// like for the other signals. If no listener accepts it, Emit returns
+// ErrNoListeners, unless the signal was created with WithBacklog, in which
+// case the payload is kept for the next listener added and Emit returns nil,
+// or ErrDropped if the backlog is full. The other methods emitting a value
+// deliver it the same way; EmitBatch delivers the whole batch to one listener.
+//
+// Example:
+//
+//	if err := jobs.Emit(ctx, job); errors.Is(err, signals.ErrNoListeners) {
+//		log.Println("no worker available")
+//	}
+func (s *QueueSignal[T]) Emit(ctx context.Context, payload T) error {
+	return s.emit(ctx, s, payload)
+}
+
+// dispatch delivers the emission to the subscriber chosen by the selection
+// policy, or adds it to the backlog if no subscriber accepts it.
+func (s *QueueSignal[T]) dispatch(ctx context.Context, subscribers []*keyedListener[T], e emission[T]) error {
+	candidates := s.candidates(subscribers, e)
+	if len(candidates) == 0 {
+		// The listeners are looked up again under the lock of the backlog,
+		// so a value is never left in the backlog by a listener added in
+		// the meantime: either it is found, or added flushes the value.
+		s.mu.Lock()
+		candidates = s.candidates(s.listeners(), e)
+		if len(candidates) == 0 {
+			defer s.mu.Unlock()
+			return s.keep(ctx, e)
+		}
+		s.mu.Unlock()
+	}
+
+	return s.deliverTo(ctx, s.choose(candidates), e)
+}
+
+// reserve returns the function dispatching the emission. Like a synchronous
+// emission, it never waits to start.
+func (s *QueueSignal[T]) reserve(subscribers []*keyedListener[T], e emission[T]) func(context.Context) error {
+	return func(ctx context.Context) error {
+		return s.dispatch(ctx, subscribers, e)
+	}
+}
+
+// candidates returns the subscribers that accept the emission and can still
+// be invoked.
+func (s *QueueSignal[T]) candidates(subscribers []*keyedListener[T], e emission[T]) []*keyedListener[T] {
+	candidates := make([]*keyedListener[T], 0, len(subscribers))
+	for _, sub := range subscribers {
+		if e.accepts(sub) && !(sub.once && sub.fired.Load()) {
+			candidates = append(candidates, sub)
+		}
+	}
+
+	return candidates
+}
+
+// choose returns the candidate selected by the policy of the signal.
+func (s *QueueSignal[T]) choose(candidates []*keyedListener[T]) *keyedListener[T] {
+	switch s.config.selection {
+	case Random:
+		return candidates[rand.Intn(len(candidates))]
+	case LeastBusy:
+		chosen := candidates[0]
+		for _, sub := range candidates[1:] {
+			if sub.running.busy() < chosen.running.busy() {
+				chosen = sub
+			}
+		}
+		return chosen
+	default:
+		return candidates[(s.next.Add(1)-1)%uint64(len(candidates))]
+	}
+}
+
+// keep adds the emission to the backlog, or returns ErrNoListeners if the
+// signal has no backlog and ErrDropped if it is full. It must be called with
+// the lock of the backlog held.
+func (s *QueueSignal[T]) keep(ctx context.Context, e emission[T]) error {
+	if s.config.backlog <= 0 {
+		return ErrNoListeners
+	}
+	if len(s.backlog) >= s.config.backlog {
+		s.stats.dropped.Add(1)
+		return ErrDropped
+	}
+
+	s.backlog = append(s.backlog, bufferedEmission[T]{ctx: detach(ctx), value: e})
+
+	return nil
+}
+
+// added delivers the values of the backlog, in the order they were emitted,
+// once a listener was added. It is called by AddListener and the other
+// methods adding a listener, in their goroutine. Each value is delivered as an
+// emission in progress, which Wait waits for; the values left once the signal
+// is closed are counted as dropped.
+func (s *QueueSignal[T]) added() {
+	s.mu.Lock()
+	backlog := s.backlog
+	s.backlog = nil
+	s.mu.Unlock()
+
+	for i, item := range backlog {
+		if !s.activity.begin() {
+			s.stats.dropped.Add(uint64(len(backlog) - i))
+			return
+		}
+		// There is no caller to return the error to, but it is not lost: the
+		// invocation counts the error of the listener in Stats and reports
+		// it to the ErrorObserver, and a value no listener accepts goes back
+		// to the backlog, or is counted as dropped if it is full.
+		_ = s.dispatch(item.ctx, s.listeners(), item.value)
+		s.activity.end()
+	}
+}
+
+// Reset removes all the listeners like Signal.Reset, and drops the values of
+// the backlog.
+func (s *QueueSignal[T]) Reset() {
+	s.BaseSignal.Reset()
+	s.dropBacklog()
+}
+
+// ResetAndCancel resets the signal like Signal.ResetAndCancel, and drops the
+// values of the backlog.
+func (s *QueueSignal[T]) ResetAndCancel(ctx context.Context) error {
+	err := s.BaseSignal.ResetAndCancel(ctx)
+	s.dropBacklog()
+
+	return err
+}
+
+// dropBacklog drops the values of the backlog.
+func (s *QueueSignal[T]) dropBacklog() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.backlog = nil
+}
+
+// Clone returns a new queue signal with the same options, listeners and
+// middlewares as the signal. See SyncSignal.Clone. The backlog is not copied.
+func (s *QueueSignal[T]) Clone() Signal[T] {
+	c := newQueueConfig[T](s.config)
+	s.copyTo(&c.BaseSignal)
+
+	return c
+}
@@ -0,0 +1,39 @@
+package signals
+
+// ResultSignal is a signal whose listeners return an error. It provides all
+// the methods of the Signal it wraps, except that AddListener and
+// AddListenerOnce accept a ResultListener. Emit invokes every listener, even
+// if a previous one failed, and returns the errors of the failed listeners
+// joined with errors.Join. The errors are joined in the order of the
+// listeners, even for a signal created with NewAsyncResult whose listeners
+// fail concurrently.
+type ResultSignal[T any] struct {
+	Signal[T]
+
+	base *BaseSignal[T]
+}
+
+// AddListener adds a listener returning an error to the signal. It accepts the
+// same options and has the same return values as Signal.AddListenerWith.
+//
+// Example:
+//
+//	signal := signals.NewResult[int]()
+//	signal.AddListener(func(ctx context.Context, payload int) error {
+//		// Listener implementation
+//		// ...
+//		return nil
+//	})
+func (s *ResultSignal[T]) AddListener(listener ResultListener[T], opts ...ListenerOption) int {
+	return s.base.add(newSubscriber(listener, newListenerConfig(opts)).of(listener))
+}
+
+// AddListenerOnce adds a listener returning an error that is invoked at most
+// once. It accepts the same options and has the same return values as
+// Signal.AddListenerOnce.
+func (s *ResultSignal[T]) AddListenerOnce(listener ResultListener[T], opts ...ListenerOption) int {
+	sub := newSubscriber(listener, newListenerConfig(opts)).of(listener)
+	sub.once = true
+
+	return s.base.add(sub)
+}
@@ -0,0 +1,208 @@
+package signals
+
+import (
+	"context"
+	"errors"
+)
+
+// StoppableSignal is a synchronous signal whose listeners can stop the
+// propagation of an emission, like the stopPropagation method of a DOM event.
+// A listener added with AddListener returns false to prevent the invocation of
+// the listeners that come after it. Since the listeners are invoked in
+// descending priority order, a listener of high priority can veto the
+// listeners of lower priority. A listener added with AddAbortableListener can
+// also abort the emission with an error returned by Emit. The listeners added
+// with the other methods, such as AddListenerFiltered, never stop the
+// propagation.
+type StoppableSignal[T any] struct {
+	BaseSignal[T]
+}
+
+// stopKey is the context key of the flag a StoppableListener sets to stop the
+// propagation of the current emission.
+type stopKey struct{}
+
+// abortKey is the context key of the error an AbortableListener sets to abort
+// the current emission.
+type abortKey struct{}
+
+// AddListener adds a listener that returns false to stop the propagation of
+// the emission to the next listeners. It accepts the same options and has the
+// same return values as Signal.AddListenerWith.
+//
+// Example:
+//
+//	signal := signals.NewStoppable[Request]()
+//	signal.AddListener(func(ctx context.Context, req Request) bool {
+//		return req.Authorized // Unauthorized requests go no further
+//	}, signals.WithPriority(100))
+func (s *StoppableSignal[T]) AddListener(listener StoppableListener[T], opts ...ListenerOption) int {
+	return s.add(newSubscriber(stoppable(listener), newListenerConfig(opts)).of(listener))
+}
+
+// AddListenerOnce adds a listener that can stop the propagation and is invoked
+// at most once. It accepts the same options and has the same return values as
+// Signal.AddListenerOnce.
+func (s *StoppableSignal[T]) AddListenerOnce(listener StoppableListener[T], opts ...ListenerOption) int {
+	sub := newSubscriber(stoppable(listener), newListenerConfig(opts)).of(listener)
+	sub.once = true
+
+	return s.add(sub)
+}
+
+// AddAbortableListener adds a listener that can stop the propagation of the
+// emission, or abort it by returning an error. When the listener returns a
+// non-nil error, the listeners that come after it are not invoked and Emit
+// returns an *AbortError with the key of the listener and its error, joined
+// with the errors of the listeners that ran before it. The stop flag is
+// ignored when the error is not nil. It accepts the same options and has the
+// same return values as Signal.AddListenerWith.
+//
+// An abort does not roll anything back: the listeners that ran before the
+// aborting listener have done their work, and undoing it, if needed, is up to
+// the caller. An abort is final, so the listener is not retried when it was
+// added with WithRetry, and in a batch the values after the one that aborted
+// are not passed to it.
+//
+// Example:
+//
+//	signal := signals.NewStoppable[Order]()
+//	signal.AddAbortableListener(func(ctx context.Context, order Order) (bool, error) {
+//		if order.Total <= 0 {
+//			return false, fmt.Errorf("invalid total %v", order.Total)
+//		}
+//		return false, nil
+//	}, signals.WithPriority(100))
+//	_, err := signal.Emit(ctx, order)
+//	var abort *signals.AbortError
+//	if errors.As(err, &abort) {
+//		log.Printf("listener %d rejected the order: %v", abort.Key, abort.Err)
+//	}
+func (s *StoppableSignal[T]) AddAbortableListener(listener AbortableListener[T], opts ...ListenerOption) int {
+	return s.add(newSubscriber(abortable(listener), newListenerConfig(opts)).of(listener))
+}
+
+// stoppable adapts a StoppableListener to a ResultListener that stops the
+// propagation of the emission when the listener returns false.
+func stoppable[T any](listener StoppableListener[T]) ResultListener[T] {
+	return func(ctx context.Context, payload T) error {
+		if !listener(ctx, payload) {
+			if stopped, ok := ctx.Value(stopKey{}).(*bool); ok {
+				*stopped = true
+			}
+		}
+		return nil
+	}
+}
+
+// abortable adapts an AbortableListener to a ResultListener that stops the
+// propagation of the emission when the listener returns true, and aborts it
+// when the listener returns an error. Once the emission was aborted, the
+// listener is not invoked again for it.
+func abortable[T any](listener AbortableListener[T]) ResultListener[T] {
+	return func(ctx context.Context, payload T) error {
+		aborted, _ := ctx.Value(abortKey{}).(*error)
+		if aborted != nil && *aborted != nil {
+			return *aborted
+		}
+
+		stop, err := listener(ctx, payload)
+		if err != nil {
+			if aborted != nil {
+				*aborted = err
+			}
+			return err
+		}
+		if stop {
+			if stopped, ok := ctx.Value(stopKey{}).(*bool); ok {
+				*stopped = true
+			}
+		}
+		return nil
+	}
+}
+
+// Emit invokes the listeners one after the other, in descending priority
+// order, until one of them stops the propagation. It returns the number of
+// listeners that ran, including the one that stopped the propagation, and the
+// errors of the listeners joined with errors.Join like SyncSignal.Emit. If a
+// listener aborted the emission, the errors include an *AbortError, which
+// matches ErrAborted. The count is 0 if the value was queued, for instance by
+// a paused signal.
+//
+// Example:
+//
+//	ran, err := signal.Emit(context.Background(), req)
+func (s *StoppableSignal[T]) Emit(ctx context.Context, payload T) (int, error) {
+	p := &propagation[T]{s: s}
+	err := s.emit(ctx, p, payload)
+
+	return p.ran, err
+}
+
+// dispatch invokes the subscribers until one of them stops the propagation.
+func (s *StoppableSignal[T]) dispatch(ctx context.Context, subscribers []*keyedListener[T], e emission[T]) error {
+	return (&propagation[T]{s: s}).dispatch(ctx, subscribers, e)
+}
+
+// reserve returns the function dispatching the emission to the subscribers. A
+// synchronous emission never waits to start.
+func (s *StoppableSignal[T]) reserve(subscribers []*keyedListener[T], e emission[T]) func(context.Context) error {
+	return (&propagation[T]{s: s}).reserve(subscribers, e)
+}
+
+// Clone returns a new stoppable signal with the same options, listeners and
+// middlewares as the signal. See SyncSignal.Clone.
+func (s *StoppableSignal[T]) Clone() *StoppableSignal[T] {
+	c := newStoppableConfig[T](s.config)
+	s.copyTo(&c.BaseSignal)
+
+	return c
+}
+
+// propagation is the dispatcher of a single emission of a StoppableSignal,
+// which counts the listeners that ran.
+type propagation[T any] struct {
+	s   *StoppableSignal[T]
+	ran int
+}
+
+// dispatch invokes the subscribers one after the other until one of them
+// stops the propagation or aborts the emission. In a batch, a listener
+// stopping the propagation for any value stops it for the whole batch.
+func (p *propagation[T]) dispatch(ctx context.Context, subscribers []*keyedListener[T], e emission[T]) error {
+	stopped := false
+	ctx = context.WithValue(ctx, stopKey{}, &stopped)
+	var aborted error
+	ctx = context.WithValue(ctx, abortKey{}, &aborted)
+
+	var errs []error
+	for _, sub := range subscribers {
+		if !e.accepts(sub) {
+			p.s.skipped(sub)
+			continue
+		}
+
+		p.ran++
+		err := p.s.deliverTo(ctx, sub, e)
+		if aborted != nil {
+			errs = append(errs, &AbortError{Key: sub.key, Err: aborted})
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if stopped {
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reserve returns the function dispatching the emission to the subscribers.
+func (p *propagation[T]) reserve(subscribers []*keyedListener[T], e emission[T]) func(context.Context) error {
+	return func(ctx context.Context) error {
+		return p.dispatch(ctx, subscribers, e)
+	}
+}
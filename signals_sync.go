@@ -1,10 +1,27 @@
 package signals
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 // SyncSignal is a struct that implements the Signal interface.
 // It provides a synchronous way of notifying all subscribers of a signal.
 // The type parameter `T` is a placeholder for any type.
+//
+// Like a sync.Mutex, the zero value of SyncSignal is an empty signal without
+// options, so an optional field of type SyncSignal[T] needs no initialization:
+// its Emit method and its methods adding, removing and querying listeners can
+// be used right away. The other methods emitting a value, such as EmitTagged
+// or EmitAndWait, rely on the construction done by NewSync, and return
+// ErrNotImplemented on the zero value. A SyncSignal must not be copied after
+// first use.
+//
+// Example:
+//
+//	type Model struct {
+//		Changed signals.SyncSignal[string] // Ready to use
+//	}
 type SyncSignal[T any] struct {
 	BaseSignal[T]
 }
@@ -20,7 +37,38 @@ type SyncSignal[T any] struct {
 // must respect it. This means that the listeners should stop processing when
 // the context is cancelled. Unlike the AsyncSignal's Emit method, this method
 // does not call the listeners in separate goroutines, so the listeners are
-// called synchronously, one after the other.
+// called synchronously, one after the other, in descending priority order
+// (see WithPriority). Listeners sharing the same priority are called in the
+// order they were added.
+//
+// Every listener is called even if a previous one failed or panicked. The
+// errors returned by the listeners (see NewResult) and the recovered panics,
+// reported as *PanicError, are joined with errors.Join, so Emit returns nil if
+// all of them succeeded. A panic raised outside of the listeners, such as by
+// a filter, is only recovered with WithIsolation. If the signal was closed,
+// Emit returns ErrSignalClosed.
+//
+// The listeners are taken from a snapshot made when the emission starts. A
+// listener added during the emission, for instance by another listener, is
+// first invoked by the next emission, and a listener removed during the
+// emission is still invoked by this one.
+//
+// No lock is held while the listeners run, so a listener may emit on the same
+// signal. The nested emission runs to completion, invoking all the listeners,
+// before Emit returns to the listener and the outer emission continues with
+// the next one. Each nested emission adds frames to the stack of the
+// goroutine, so a listener emitting recursively must bound the depth of the
+// recursion, for instance with a counter in the payload; Go grows the stack as
+// needed, but an unbounded recursion eventually exceeds its maximum size and
+// crashes the program.
+//
+// Concurrent emissions do not wait for each other: each one runs the listeners
+// in the goroutine of its caller, and the locks it takes to start and finish
+// are only held for a few instructions, never while a listener runs. Those
+// locks are sync.Mutex and sync.RWMutex, which hand the lock over in FIFO
+// order to a goroutine that waited for more than a millisecond, so no emitter
+// is starved. There is no ordering between the emissions of different
+// goroutines: the listeners may receive them in any order.
 //
 // Example:
 //
@@ -32,9 +80,45 @@ type SyncSignal[T any] struct {
 //
 //	signal.Emit(context.Background(), "Hello, world!")
 func (s *SyncSignal[T]) Emit(ctx context.Context, payload T) error {
-	for _, sub := range s.subscribers {
-		sub.listener(ctx, payload)
+	return s.emit(ctx, s, payload)
+}
+
+// dispatch calls the subscribers one after the other.
+func (s *SyncSignal[T]) dispatch(ctx context.Context, subscribers []*keyedListener[T], e emission[T]) error {
+	var errs []error
+	for _, sub := range subscribers {
+		if err := s.deliverIsolated(ctx, sub, e); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// deliverIsolated invokes the subscriber if it accepts the emission. If the
+// signal was created with WithIsolation, a panic raised while delivering the
+// emission is recovered as a *PanicError of the subscriber.
+func (s *SyncSignal[T]) deliverIsolated(ctx context.Context, sub *keyedListener[T], e emission[T]) (err error) {
+	if s.config.isolated {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = s.config.panicked(sub.key, recovered)
+			}
+		}()
+	}
+
+	if !e.accepts(sub) {
+		s.skipped(sub)
+		return nil
+	}
+
+	return s.deliverTo(ctx, sub, e)
+}
+
+// reserve returns the function dispatching the emission to the subscribers. A
+// synchronous emission never waits to start.
+func (s *SyncSignal[T]) reserve(subscribers []*keyedListener[T], e emission[T]) func(context.Context) error {
+	return func(ctx context.Context) error {
+		return s.dispatch(ctx, subscribers, e)
+	}
 }
@@ -2,6 +2,11 @@ package signals_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -108,6 +113,7 @@ func TestSignalAsyncWithTimeout(t *testing.T) {
 }
 
 func TestAddRemoveListener(t *testing.T) {
+	const listenerKey signals.Key[int] = 1
 	testSignal := signals.New[int]()
 
 	t.Run("AddListener", func(t *testing.T) {
@@ -115,27 +121,35 @@ func TestAddRemoveListener(t *testing.T) {
 			// Do something
 		})
 
-		testSignal.AddListener(func(ctx context.Context, v int) {
+		listenerKey.AddTo(testSignal, func(ctx context.Context, v int) {
 			// Do something
-		}, signals.SignalType(1))
+		})
 
 		if testSignal.Len() != 2 {
 			t.Error("Count must be 2")
 		}
 
-		if count := testSignal.AddListener(func(ctx context.Context, v int) {
+		if count := listenerKey.AddTo(testSignal, func(ctx context.Context, v int) {
 
-		}, signals.SignalType(1)); count != -1 {
+		}); count != -1 {
 			t.Error("Count must be -1")
 		}
 	})
 
 	t.Run("RemoveListener", func(t *testing.T) {
-		if count := testSignal.RemoveListener(signals.SignalType(1)); count != 1 {
+		if !listenerKey.AddedTo(testSignal) {
+			t.Error("Listener 1 must be registered")
+		}
+
+		if count := listenerKey.RemoveFrom(testSignal); count != 1 {
 			t.Error("Count must be 1")
 		}
 
-		if count := testSignal.RemoveListener(signals.SignalType(1)); count != -1 {
+		if listenerKey.AddedTo(testSignal) {
+			t.Error("Listener 1 must be removed")
+		}
+
+		if count := listenerKey.RemoveFrom(testSignal); count != -1 {
 			t.Error("Count must be -1")
 		}
 	})
@@ -154,5 +168,3930 @@ func TestAddRemoveListener(t *testing.T) {
 func TestBaseSignal(t *testing.T) {
 	testSignal := signals.BaseSignal[int]{}
 
-	require.Error(t, testSignal.Emit(context.Background(), 1))
+	require.ErrorIs(t, testSignal.Emit(context.Background(), 1), signals.ErrNotImplemented)
+	require.ErrorIs(t, testSignal.EmitWithTimeout(context.Background(), 1, time.Second), signals.ErrNotImplemented)
+}
+
+func TestSignalLIFO(t *testing.T) {
+	testSignal := signals.NewSync[int](signals.WithLIFO())
+
+	results := make([]string, 0)
+	record := func(name string) signals.SignalListener[int] {
+		return func(ctx context.Context, v int) {
+			results = append(results, name)
+		}
+	}
+
+	testSignal.AddListener(record("first"), signals.SignalType(1))
+	testSignal.AddListener(record("second"), signals.SignalType(2))
+	testSignal.AddListenerWith(record("urgent"), signals.SignalType(3), signals.WithPriority(10))
+	testSignal.AddListener(record("third"), signals.SignalType(4))
+	testSignal.AddListenerWith(record("urgent-2"), signals.SignalType(5), signals.WithPriority(10))
+
+	require.Equal(t, 5, testSignal.Len())
+	require.Equal(t, []signals.SignalType{5, 3, 4, 2, 1}, testSignal.Keys())
+	require.NoError(t, testSignal.Emit(context.Background(), 1))
+	require.Equal(t, []string{"urgent-2", "urgent", "third", "second", "first"}, results)
+
+	require.Equal(t, 4, testSignal.RemoveListener(4))
+	results = results[:0]
+	require.NoError(t, testSignal.Emit(context.Background(), 1))
+	require.Equal(t, []string{"urgent-2", "urgent", "second", "first"}, results)
+
+	testSignal.Reset()
+	require.Equal(t, 0, testSignal.Len())
+	testSignal.AddListener(record("after-reset"))
+	testSignal.AddListener(record("last"))
+	results = results[:0]
+	require.NoError(t, testSignal.Emit(context.Background(), 1))
+	require.Equal(t, []string{"last", "after-reset"}, results)
+}
+
+func TestZeroValueSignal(t *testing.T) {
+	ctx := context.Background()
+
+	var syncSignal signals.SyncSignal[int]
+	require.True(t, syncSignal.IsEmpty())
+	require.NoError(t, syncSignal.Emit(ctx, 1))
+
+	results := make([]int, 0)
+	require.Equal(t, 1, syncSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, v)
+	}, signals.SignalType(1)))
+	require.Equal(t, -1, syncSignal.AddListener(func(ctx context.Context, v int) {}, signals.SignalType(1)))
+	require.NoError(t, syncSignal.Emit(ctx, 2))
+	require.Equal(t, []int{2}, results)
+	require.Equal(t, 0, syncSignal.RemoveListener(1))
+
+	var asyncSignal signals.AsyncSignal[int]
+	require.NoError(t, asyncSignal.Emit(ctx, 1))
+	var count atomic.Int32
+	asyncSignal.AddListener(func(ctx context.Context, v int) {
+		count.Add(1)
+	})
+	asyncSignal.AddListener(func(ctx context.Context, v int) {
+		count.Add(1)
+	})
+	require.NoError(t, asyncSignal.Emit(ctx, 1))
+	require.Equal(t, int32(2), count.Load())
+	asyncSignal.Reset()
+	require.True(t, asyncSignal.IsEmpty())
+}
+
+func TestSignalPriority(t *testing.T) {
+	testSignal := signals.NewSync[int]()
+
+	results := make([]string, 0)
+	record := func(name string) signals.SignalListener[int] {
+		return func(ctx context.Context, v int) {
+			results = append(results, name)
+		}
+	}
+
+	testSignal.AddListener(record("persist"))
+	testSignal.AddListenerWith(record("validate"), signals.WithPriority(10))
+	testSignal.AddListenerWith(record("audit"), signals.SignalType(1), signals.WithPriority(-5))
+	testSignal.AddListener(record("persist-2"))
+	testSignal.AddListenerWith(record("validate-2"), signals.SignalType(2), signals.WithPriority(10))
+
+	require.Equal(t, 5, testSignal.Len())
+	require.NoError(t, testSignal.Emit(context.Background(), 1))
+	require.Equal(t, []string{"validate", "validate-2", "persist", "persist-2", "audit"}, results)
+
+	require.Equal(t, 4, testSignal.RemoveListener(signals.SignalType(2)))
+	require.Equal(t, -1, testSignal.AddListenerWith(record("duplicate"), signals.SignalType(1), signals.WithPriority(100)))
+
+	results = results[:0]
+	require.NoError(t, testSignal.Emit(context.Background(), 1))
+	require.Equal(t, []string{"validate", "persist", "persist-2", "audit"}, results)
+}
+
+func TestAddListenerOnce(t *testing.T) {
+	t.Run("Sync", func(t *testing.T) {
+		testSignal := signals.NewSync[int]()
+
+		results := make([]int, 0)
+		testSignal.AddListenerOnce(func(ctx context.Context, v int) {
+			results = append(results, v)
+		}, signals.SignalType(1))
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			results = append(results, -v)
+		})
+
+		ctx := context.Background()
+		require.NoError(t, testSignal.Emit(ctx, 1))
+		require.Equal(t, 1, testSignal.Len())
+		require.Equal(t, -1, testSignal.RemoveListener(signals.SignalType(1)))
+		require.NoError(t, testSignal.Emit(ctx, 2))
+
+		require.Equal(t, []int{1, -1, -2}, results)
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		testSignal := signals.New[int]()
+
+		var count atomic.Int32
+		testSignal.AddListenerOnce(func(ctx context.Context, v int) {
+			count.Add(1)
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				assert.NoError(t, testSignal.Emit(context.Background(), i))
+			}(i)
+		}
+		wg.Wait()
+
+		require.Equal(t, int32(1), count.Load())
+		require.True(t, testSignal.IsEmpty())
+	})
+}
+
+func TestResultSignal(t *testing.T) {
+	testSignal := signals.NewResult[int]()
+
+	errFirst := errors.New("first")
+	errThird := errors.New("third")
+
+	calls := 0
+	testSignal.AddListener(func(ctx context.Context, v int) error {
+		calls++
+		return errFirst
+	})
+	testSignal.AddListener(func(ctx context.Context, v int) error {
+		calls++
+		return nil
+	})
+	testSignal.AddListener(func(ctx context.Context, v int) error {
+		calls++
+		if v > 1 {
+			return errThird
+		}
+		return nil
+	}, signals.SignalType(1))
+
+	ctx := context.Background()
+	err := testSignal.Emit(ctx, 2)
+	require.Equal(t, 3, calls)
+	require.ErrorIs(t, err, errFirst)
+	require.ErrorIs(t, err, errThird)
+
+	require.Equal(t, 2, testSignal.RemoveListener(signals.SignalType(1)))
+	require.ErrorIs(t, testSignal.Emit(ctx, 2), errFirst)
+
+	testSignal.Reset()
+	testSignal.AddListener(func(ctx context.Context, v int) error {
+		return nil
+	})
+	assert.NoError(t, testSignal.Emit(ctx, 1))
+}
+
+func TestSignalPanicRecovery(t *testing.T) {
+	t.Run("Sync", func(t *testing.T) {
+		const (
+			boomKey signals.Key[int] = 2
+			bangKey signals.Key[int] = 4
+		)
+
+		var hookKey signals.SignalType
+		var hookValue any
+		testSignal := signals.NewSync[int](signals.WithOnPanic(func(recovered any, key signals.SignalType) {
+			hookKey, hookValue = key, recovered
+		}))
+
+		results := make([]int, 0)
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			results = append(results, 1)
+		})
+		boomKey.AddTo(testSignal, func(ctx context.Context, v int) {
+			panic("boom")
+		})
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			results = append(results, 3)
+		})
+
+		err := testSignal.Emit(context.Background(), 1)
+		require.Equal(t, []int{1, 3}, results)
+
+		var panicErr *signals.PanicError
+		require.ErrorAs(t, err, &panicErr)
+		require.Equal(t, boomKey.Type(), panicErr.Key)
+		require.Equal(t, "boom", panicErr.Value)
+		require.Equal(t, boomKey.Type(), hookKey)
+		require.Equal(t, "boom", hookValue)
+
+		// Each listener is isolated: every panic is recovered and reported,
+		// and the listeners after them still run.
+		bangKey.AddTo(testSignal, func(ctx context.Context, v int) {
+			panic("bang")
+		})
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			results = append(results, 5)
+		})
+		results = results[:0]
+		err = testSignal.Emit(context.Background(), 1)
+		require.Equal(t, []int{1, 3, 5}, results)
+		joined, ok := err.(interface{ Unwrap() []error })
+		require.True(t, ok)
+		require.Len(t, joined.Unwrap(), 2)
+		require.Equal(t, bangKey.Type(), hookKey)
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		testSignal := signals.New[int]()
+
+		var count atomic.Int32
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			panic(errBoom)
+		})
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			count.Add(1)
+		})
+
+		err := testSignal.Emit(context.Background(), 1)
+		require.ErrorIs(t, err, errBoom)
+		require.Equal(t, int32(1), count.Load())
+	})
+}
+
+func TestCollector(t *testing.T) {
+	for name, collector := range map[string]*signals.Collector[int, int]{
+		"Sync":  signals.NewCollector[int, int](),
+		"Async": signals.NewAsyncCollector[int, int](),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			results, err := collector.Emit(ctx, 1)
+			require.NoError(t, err)
+			require.NotNil(t, results)
+			require.Empty(t, results)
+
+			collector.AddListener(func(ctx context.Context, v int) int {
+				time.Sleep(20 * time.Millisecond)
+				return v * 2
+			})
+			collector.AddListener(func(ctx context.Context, v int) int {
+				return v * 3
+			}, signals.SignalType(1))
+			collector.AddListener(func(ctx context.Context, v int) int {
+				return v
+			}, signals.WithPriority(1))
+
+			results, err = collector.Emit(ctx, 5)
+			require.NoError(t, err)
+			require.Equal(t, []int{5, 10, 15}, results)
+
+			require.Equal(t, 2, collector.RemoveListener(signals.SignalType(1)))
+			collector.AddListener(func(ctx context.Context, v int) int {
+				panic("boom")
+			})
+
+			results, err = collector.Emit(ctx, 5)
+			var panicErr *signals.PanicError
+			require.ErrorAs(t, err, &panicErr)
+			require.Equal(t, []int{5, 10, 0}, results)
+
+			collector.Reset()
+			require.True(t, collector.IsEmpty())
+		})
+	}
+}
+
+// cancelledAfter is a context reporting context.Canceled once its Err method
+// was called n times, to cancel an emission at a precise point.
+type cancelledAfter struct {
+	context.Context
+	n atomic.Int32
+}
+
+func (c *cancelledAfter) Err() error {
+	if c.n.Add(-1) < 0 {
+		return context.Canceled
+	}
+
+	return nil
+}
+
+func TestCollectorCancelled(t *testing.T) {
+	collector := signals.NewAsyncCollector[int, int]()
+	collector.AddListener(func(ctx context.Context, v int) int {
+		panic("boom")
+	})
+	collector.AddListener(func(ctx context.Context, v int) int {
+		t.Error("listener started after the cancellation")
+		return v
+	})
+
+	// The first listener is started, then the context is cancelled.
+	ctx := &cancelledAfter{Context: context.Background()}
+	ctx.n.Store(1)
+	results, err := collector.Emit(ctx, 5)
+	require.ErrorIs(t, err, context.Canceled)
+	var panicErr *signals.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	require.Equal(t, []int{0, 0}, results)
+}
+
+func TestSubscribe(t *testing.T) {
+	testSignal := signals.New[int]()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	values := testSignal.Subscribe(ctx, 2)
+	require.Equal(t, 1, testSignal.Len())
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, testSignal.Emit(context.Background(), i))
+	}
+
+	// The buffer holds 2 values, so the third one was dropped.
+	require.Equal(t, 1, <-values)
+	require.Equal(t, 2, <-values)
+
+	unbuffered := testSignal.Subscribe(ctx, 0)
+	require.NoError(t, testSignal.Emit(context.Background(), 4))
+	require.Equal(t, 4, <-values)
+	select {
+	case v := <-unbuffered:
+		t.Fatalf("unexpected value %d", v)
+	default:
+	}
+
+	cancel()
+	_, ok := <-values
+	require.False(t, ok)
+	_, ok = <-unbuffered
+	require.False(t, ok)
+	require.True(t, testSignal.IsEmpty())
+
+	// A subscription the signal refuses is closed right away.
+	full := signals.New[int](signals.WithMaxListeners(1))
+	full.AddListener(func(ctx context.Context, v int) {})
+	_, ok = <-full.Subscribe(context.Background(), 1)
+	require.False(t, ok)
+	refused, dropped := full.SubscribeWithDrops(context.Background(), 1)
+	_, ok = <-refused
+	require.False(t, ok)
+	_, ok = <-dropped
+	require.False(t, ok)
+	require.Equal(t, 1, full.Len())
+
+	// A negative size means an unbuffered channel.
+	unbufferedSignal := signals.New[int](signals.WithHistory(2))
+	require.Zero(t, cap(unbufferedSignal.Subscribe(context.Background(), -1)))
+	withDrops, _ := unbufferedSignal.SubscribeWithDrops(context.Background(), -1)
+	require.Zero(t, cap(withDrops))
+	require.Equal(t, 2, cap(unbufferedSignal.SubscribeWithHistory(context.Background(), -1)))
+	require.NoError(t, unbufferedSignal.Emit(context.Background(), 1))
+}
+
+func TestStickySignal(t *testing.T) {
+	testSignal := signals.NewSticky[int]()
+
+	results := make([]int, 0)
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, v)
+	})
+	require.Empty(t, results, "nothing is replayed before the first emission")
+
+	ctx := context.Background()
+	require.NoError(t, testSignal.Emit(ctx, 0))
+	require.NoError(t, testSignal.Emit(ctx, 7))
+
+	late := make([]int, 0)
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		late = append(late, v)
+	})
+	require.Equal(t, []int{7}, late)
+
+	require.NoError(t, testSignal.Emit(ctx, 8))
+	require.Equal(t, []int{0, 7, 8}, results)
+	require.Equal(t, []int{7, 8}, late)
+
+	testSignal.Reset()
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		t.Fatal("the stored value must be cleared by Reset")
+	})
+
+	asyncSignal := signals.New[int](signals.WithReplay())
+	require.NoError(t, asyncSignal.Emit(ctx, 0))
+
+	var replayed atomic.Bool
+	asyncSignal.AddListener(func(ctx context.Context, v int) {
+		replayed.Store(v == 0)
+	})
+	require.True(t, replayed.Load(), "the zero value is replayed once emitted")
+}
+
+func TestSignalAsyncMaxConcurrency(t *testing.T) {
+	testSignal := signals.New[int](signals.WithMaxConcurrency(2))
+
+	var running, peak atomic.Int32
+	for i := 0; i < 6; i++ {
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			running.Add(-1)
+		})
+	}
+
+	require.NoError(t, testSignal.Emit(context.Background(), 1))
+	require.Equal(t, int32(2), peak.Load())
+	require.Equal(t, int32(0), running.Load())
+}
+
+// BenchmarkSignalAsyncMaxConcurrency emits from many goroutines and reports
+// the highest number of goroutines observed, which stays bounded by the
+// emitters plus the concurrency limit.
+func BenchmarkSignalAsyncMaxConcurrency(b *testing.B) {
+	testSignal := signals.New[int](signals.WithMaxConcurrency(8))
+	for i := 0; i < 32; i++ {
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			time.Sleep(time.Microsecond)
+		})
+	}
+
+	var peak atomic.Int64
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				if n := int64(runtime.NumGoroutine()); n > peak.Load() {
+					peak.Store(n)
+				}
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	ctx := context.Background()
+	b.SetParallelism(4)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = testSignal.Emit(ctx, 1)
+		}
+	})
+	close(done)
+
+	b.ReportMetric(float64(peak.Load()), "max-goroutines")
+}
+
+// BenchmarkSignalLen calls Len from many goroutines while another goroutine
+// keeps adding and removing a listener, which holds the lock of the signal.
+// The RWMutex sub-benchmark is the baseline: the same count read under a read
+// lock contended the same way.
+func BenchmarkSignalLen(b *testing.B) {
+	b.Run("Signal", func(b *testing.B) {
+		testSignal := signals.NewSync[int]()
+		for i := 0; i < 8; i++ {
+			testSignal.AddListener(func(ctx context.Context, v int) {})
+		}
+
+		benchmarkLen(b, testSignal.Len, func() {
+			testSignal.AddListener(func(ctx context.Context, v int) {}, signals.SignalType(1))
+			testSignal.RemoveListener(1)
+		})
+	})
+
+	b.Run("RWMutex", func(b *testing.B) {
+		var mu sync.RWMutex
+		listeners := make([]int, 8)
+
+		benchmarkLen(b, func() int {
+			mu.RLock()
+			defer mu.RUnlock()
+			return len(listeners)
+		}, func() {
+			mu.Lock()
+			listeners = append(listeners, 1)
+			mu.Unlock()
+			mu.Lock()
+			listeners = listeners[:len(listeners)-1]
+			mu.Unlock()
+		})
+	})
+}
+
+// benchmarkLen calls length from many goroutines while another goroutine keeps
+// calling churn.
+func benchmarkLen(b *testing.B, length func() int, churn func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				churn()
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if length() < 8 {
+				b.Error("unexpected length")
+			}
+		}
+	})
+	b.StopTimer()
+	close(done)
+	wg.Wait()
+}
+
+func TestSignalCloseAndWait(t *testing.T) {
+	testSignal := signals.New[int]()
+
+	release := make(chan struct{})
+	var finished atomic.Bool
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		<-release
+		finished.Store(true)
+	})
+
+	ctx := context.Background()
+	go func() {
+		assert.NoError(t, testSignal.Emit(ctx, 1))
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	testSignal.Close()
+	testSignal.Close()
+	require.ErrorIs(t, testSignal.Emit(ctx, 2), signals.ErrSignalClosed)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, testSignal.Wait(waitCtx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, testSignal.Wait(ctx))
+	require.True(t, finished.Load())
+
+	testSignal.Reset()
+	require.ErrorIs(t, testSignal.Emit(ctx, 3), signals.ErrSignalClosed)
+}
+
+func TestAddCloseListener(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int]()
+
+	release := make(chan struct{})
+	var finished atomic.Bool
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		<-release
+		finished.Store(true)
+	})
+
+	var mu sync.Mutex
+	closed := make([]string, 0)
+	for _, name := range []string{"first", "second"} {
+		name := name
+		require.True(t, testSignal.AddCloseListener(func(ctx context.Context) {
+			// The emission in progress finished before.
+			assert.True(t, finished.Load())
+
+			mu.Lock()
+			defer mu.Unlock()
+			closed = append(closed, name)
+		}))
+	}
+
+	go func() {
+		assert.NoError(t, testSignal.Emit(ctx, 1))
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	testSignal.Close()
+	testSignal.Close()
+	require.False(t, testSignal.AddCloseListener(func(ctx context.Context) {
+		t.Error("added after close")
+	}))
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, testSignal.Wait(waitCtx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, testSignal.Wait(ctx))
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"first", "second"}, closed)
+}
+
+func TestDebouncedSignal(t *testing.T) {
+	testSignal := signals.NewDebounced[int](30 * time.Millisecond)
+
+	var mu sync.Mutex
+	results := make([]int, 0)
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		mu.Lock()
+		defer mu.Unlock()
+		results = append(results, v)
+	})
+	snapshot := func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), results...)
+	}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 1; i <= 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, testSignal.Emit(ctx, i))
+		}(i)
+	}
+	wg.Wait()
+	require.NoError(t, testSignal.Emit(ctx, 10))
+	require.Empty(t, snapshot())
+
+	require.Eventually(t, func() bool { return len(snapshot()) == 1 }, time.Second, 5*time.Millisecond)
+	require.Equal(t, []int{10}, snapshot())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	require.NoError(t, testSignal.Emit(cancelled, 11))
+	cancel()
+	time.Sleep(60 * time.Millisecond)
+	require.Equal(t, []int{10}, snapshot(), "a cancelled emission must not fire")
+
+	require.NoError(t, testSignal.Emit(ctx, 12))
+	testSignal.Close()
+	require.Equal(t, []int{10, 12}, snapshot(), "Close flushes the pending value")
+	require.ErrorIs(t, testSignal.Emit(ctx, 13), signals.ErrSignalClosed)
+}
+
+func TestThrottledSignal(t *testing.T) {
+	testSignal := signals.NewThrottled[int](50 * time.Millisecond)
+
+	var mu sync.Mutex
+	results := make([]int, 0)
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		mu.Lock()
+		defer mu.Unlock()
+		results = append(results, v)
+	})
+	snapshot := func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), results...)
+	}
+
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, testSignal.Emit(ctx, i))
+	}
+	require.Equal(t, []int{1}, snapshot(), "the first emission is not delayed")
+
+	require.Eventually(t, func() bool { return len(snapshot()) == 2 }, time.Second, 5*time.Millisecond)
+	require.Equal(t, []int{1, 5}, snapshot(), "the trailing emission carries the latest value")
+
+	require.NoError(t, testSignal.Emit(ctx, 6))
+	require.NoError(t, testSignal.Emit(ctx, 7))
+	testSignal.Close()
+	require.Equal(t, []int{1, 5, 7}, snapshot(), "Close flushes the trailing value")
+	require.ErrorIs(t, testSignal.Emit(ctx, 8), signals.ErrSignalClosed)
+}
+
+func TestDelayedCompareAndEmit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Debounced", func(t *testing.T) {
+		testSignal := signals.Debounce[int](signals.NewSticky[int](), 30*time.Millisecond)
+		var count atomic.Int32
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			count.Add(1)
+		})
+
+		ok, err := testSignal.CompareAndEmit(ctx, 0, 1)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Zero(t, count.Load(), "the value is delayed")
+		require.Eventually(t, func() bool { return count.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+		// The comparison happens once the delay elapses.
+		ok, err = testSignal.CompareAndEmit(ctx, 5, 2)
+		require.NoError(t, err)
+		require.True(t, ok)
+		time.Sleep(60 * time.Millisecond)
+		require.Equal(t, int32(1), count.Load())
+		testSignal.Close()
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		testSignal := signals.Throttle[int](signals.NewSticky[int](), 50*time.Millisecond)
+		var mu sync.Mutex
+		results := make([]int, 0)
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, v)
+		})
+		snapshot := func() []int {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]int(nil), results...)
+		}
+
+		ok, err := testSignal.CompareAndEmit(ctx, 0, 1)
+		require.NoError(t, err)
+		require.True(t, ok)
+		ok, err = testSignal.CompareAndEmit(ctx, 1, 2)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []int{1}, snapshot(), "the second value waits for the interval")
+
+		require.Eventually(t, func() bool { return len(snapshot()) == 2 }, time.Second, 5*time.Millisecond)
+		require.Equal(t, []int{1, 2}, snapshot())
+		testSignal.Close()
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		// NullSignal is not a StickySignal and has no EmitFactory method.
+		debounced := signals.Debounce[int](signals.NewNull[int](), time.Millisecond)
+		_, err := debounced.CompareAndEmit(ctx, 0, 1)
+		require.ErrorIs(t, err, signals.ErrNotSticky)
+		require.ErrorIs(t, debounced.EmitFactory(ctx, func() int { return 1 }), signals.ErrNotImplemented)
+
+		throttled := signals.Throttle[int](signals.NewNull[int](), time.Millisecond)
+		_, err = throttled.CompareAndEmit(ctx, 0, 1)
+		require.ErrorIs(t, err, signals.ErrNotSticky)
+		_, err = throttled.EmitN(ctx, 1)
+		require.ErrorIs(t, err, signals.ErrNotImplemented)
+		_, err = throttled.TryEmit(ctx, 1)
+		require.ErrorIs(t, err, signals.ErrNotImplemented)
+	})
+}
+
+func TestSignalKeys(t *testing.T) {
+	testSignal := signals.New[int]()
+	require.Empty(t, testSignal.Keys())
+
+	listener := func(ctx context.Context, v int) {}
+	testSignal.AddListener(listener, signals.SignalType(3))
+	testSignal.AddListener(listener)
+	testSignal.AddListenerWith(listener, signals.SignalType(1), signals.WithPriority(1))
+	testSignal.AddListener(listener, signals.SignalType(2))
+
+	keys := testSignal.Keys()
+	require.Equal(t, []signals.SignalType{1, 3, 2}, keys)
+
+	keys[0] = 42
+	require.Equal(t, []signals.SignalType{1, 3, 2}, testSignal.Keys())
+
+	testSignal.RemoveListener(signals.SignalType(3))
+	require.Equal(t, []signals.SignalType{1, 2}, testSignal.Keys())
+}
+
+func TestAddListenerFiltered(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+
+	t.Run("Sync", func(t *testing.T) {
+		testSignal := signals.NewSync[int]()
+
+		results := make([]int, 0)
+		testSignal.AddListenerFiltered(func(ctx context.Context, v int) {
+			results = append(results, v)
+		}, even, signals.SignalType(1))
+
+		for i := 1; i <= 4; i++ {
+			require.NoError(t, testSignal.Emit(context.Background(), i))
+		}
+		require.Equal(t, []int{2, 4}, results)
+		require.Equal(t, 1, testSignal.Len())
+		require.Equal(t, 0, testSignal.RemoveListener(signals.SignalType(1)))
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		testSignal := signals.New[int]()
+
+		var sum atomic.Int32
+		testSignal.AddListenerFiltered(func(ctx context.Context, v int) {
+			sum.Add(int32(v))
+		}, even)
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			sum.Add(100)
+		})
+
+		for i := 1; i <= 4; i++ {
+			require.NoError(t, testSignal.Emit(context.Background(), i))
+		}
+		require.Equal(t, int32(406), sum.Load())
+	})
+}
+
+func TestMap(t *testing.T) {
+	source := signals.New[int]()
+	source.AddListener(func(ctx context.Context, v int) {}, signals.SignalType(1))
+	source.AddListener(func(ctx context.Context, v int) {}, signals.SignalType(-5))
+
+	mapped, closeMapped := signals.Map(source, func(v int) string {
+		return strings.Repeat("x", v)
+	})
+
+	type ctxKey struct{}
+	results := make([]string, 0)
+	mapped.AddListener(func(ctx context.Context, v string) {
+		require.Equal(t, "value", ctx.Value(ctxKey{}))
+		results = append(results, v)
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	require.NoError(t, source.Emit(ctx, 2))
+	require.NoError(t, source.Emit(ctx, 3))
+	require.Equal(t, []string{"xx", "xxx"}, results)
+	require.Equal(t, 3, source.Len())
+	require.Equal(t, []signals.SignalType{1, -5}, source.Keys())
+	require.True(t, source.HasListener(-5))
+
+	closeMapped()
+	closeMapped()
+	require.Equal(t, 2, source.Len())
+	require.NoError(t, source.Emit(ctx, 4))
+	require.Len(t, results, 2)
+}
+
+func TestSplit(t *testing.T) {
+	source := signals.NewSync[string]()
+	split, closeSplit := signals.Split(source, func(v string) []string {
+		return strings.Fields(v)
+	})
+
+	type ctxKey struct{}
+	results := make([]string, 0)
+	split.AddListener(func(ctx context.Context, v string) {
+		require.Equal(t, "value", ctx.Value(ctxKey{}))
+		results = append(results, v)
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	require.NoError(t, source.Emit(ctx, "a b c"))
+	require.NoError(t, source.Emit(ctx, ""))
+	require.NoError(t, source.Emit(ctx, "d"))
+	require.Equal(t, []string{"a", "b", "c", "d"}, results)
+
+	closeSplit()
+	closeSplit()
+	require.True(t, source.IsEmpty())
+	require.NoError(t, source.Emit(ctx, "e f"))
+	require.Len(t, results, 4)
+}
+
+func TestTap(t *testing.T) {
+	ctx := context.Background()
+	ints := signals.NewSync[int]()
+	names := signals.New[string]()
+
+	var mu sync.Mutex
+	tapped := make([]any, 0)
+	sink := func(ctx context.Context, value any) {
+		mu.Lock()
+		defer mu.Unlock()
+		tapped = append(tapped, value)
+	}
+	stopInts := ints.Tap(sink)
+	stopNames := names.Tap(sink)
+
+	typed := 0
+	ints.AddListener(func(ctx context.Context, v int) {
+		typed += v
+	})
+
+	require.NoError(t, ints.Emit(ctx, 1))
+	require.NoError(t, names.Emit(ctx, "a"))
+	require.Equal(t, []any{1, "a"}, tapped)
+	require.Equal(t, 1, typed)
+
+	stopInts()
+	stopInts()
+	stopNames()
+	require.Equal(t, 1, ints.Len())
+	require.True(t, names.IsEmpty())
+	require.NoError(t, ints.Emit(ctx, 2))
+	require.Len(t, tapped, 2)
+	require.Equal(t, 3, typed)
+
+	erased, closeErased := signals.ToAny[int](ints)
+	var received any
+	erased.AddListener(func(ctx context.Context, value any) {
+		received = value
+	})
+	require.NoError(t, ints.Emit(ctx, 3))
+	require.Equal(t, 3, received)
+	closeErased()
+	require.Equal(t, 1, ints.Len())
+}
+
+func TestMerge(t *testing.T) {
+	empty, closeEmpty := signals.Merge[int]()
+	require.True(t, empty.IsEmpty())
+	require.NoError(t, empty.Emit(context.Background(), 1))
+	closeEmpty()
+
+	first := signals.New[int]()
+	second := signals.NewSync[int]()
+	merged, closeMerged := signals.Merge[int](first, second)
+
+	var sum atomic.Int32
+	merged.AddListener(func(ctx context.Context, v int) {
+		sum.Add(int32(v))
+	})
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, first.Emit(context.Background(), i))
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, second.Emit(context.Background(), i))
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, int32(110), sum.Load())
+
+	closeMerged()
+	closeMerged()
+	require.True(t, first.IsEmpty())
+	require.True(t, second.IsEmpty())
+}
+
+func TestPipe(t *testing.T) {
+	source := signals.NewSync[int]()
+	destination := signals.NewResult[int]()
+
+	errOdd := errors.New("odd")
+	results := make([]int, 0)
+	destination.AddListener(func(ctx context.Context, v int) error {
+		results = append(results, v)
+		if v%2 != 0 {
+			return errOdd
+		}
+		return nil
+	})
+
+	var errs []error
+	stop := source.Pipe(destination.Signal, func(err error) {
+		errs = append(errs, err)
+	})
+
+	ctx := context.Background()
+	require.NoError(t, source.Emit(ctx, 1))
+	require.NoError(t, source.Emit(ctx, 2))
+	require.Equal(t, []int{1, 2}, results)
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], errOdd)
+
+	stop()
+	stop()
+	require.True(t, source.IsEmpty())
+	require.NoError(t, source.Emit(ctx, 3))
+	require.Equal(t, []int{1, 2}, results)
+}
+
+func TestEmitWithTimeout(t *testing.T) {
+	testSignal := signals.New[int]()
+
+	var count, timeoutCount atomic.Int32
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		select {
+		case <-time.After(100 * time.Millisecond):
+			count.Add(1)
+		case <-ctx.Done():
+			timeoutCount.Add(1)
+		}
+	})
+
+	ctx := context.Background()
+	require.NoError(t, testSignal.EmitWithTimeout(ctx, 1, 50*time.Millisecond))
+	require.NoError(t, testSignal.EmitWithTimeout(ctx, 1, time.Second))
+	require.Equal(t, int32(1), count.Load())
+	require.Equal(t, int32(1), timeoutCount.Load())
+
+	debounced := signals.Debounce[int](testSignal, 10*time.Millisecond)
+	require.NoError(t, debounced.EmitWithTimeout(ctx, 1, time.Second))
+	require.Eventually(t, func() bool { return count.Load() == 2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestListenerTimeout(t *testing.T) {
+	testSignal := signals.New[int]()
+
+	var slowTimedOut, fastTimedOut atomic.Bool
+	testSignal.AddListenerWith(func(ctx context.Context, v int) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-ctx.Done():
+			slowTimedOut.Store(true)
+		}
+	}, signals.WithListenerTimeout(20*time.Millisecond))
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		select {
+		case <-time.After(50 * time.Millisecond):
+		case <-ctx.Done():
+			fastTimedOut.Store(true)
+		}
+	})
+
+	require.NoError(t, testSignal.Emit(context.Background(), 1))
+	require.True(t, slowTimedOut.Load())
+	require.False(t, fastTimedOut.Load())
+
+	// The emission context wins when its deadline is shorter.
+	slowTimedOut.Store(false)
+	testSignal.Reset()
+	testSignal.AddListenerWith(func(ctx context.Context, v int) {
+		deadline, _ := ctx.Deadline()
+		assert.Less(t, time.Until(deadline), 100*time.Millisecond)
+		<-ctx.Done()
+		slowTimedOut.Store(true)
+	}, signals.WithListenerTimeout(time.Hour))
+
+	require.NoError(t, testSignal.EmitWithTimeout(context.Background(), 1, 20*time.Millisecond))
+	require.True(t, slowTimedOut.Load())
+}
+
+func TestMiddleware(t *testing.T) {
+	testSignal := signals.NewResult[int]()
+
+	type ctxKey struct{}
+	trace := make([]string, 0)
+	testSignal.Use(func(next signals.SignalListener[int]) signals.SignalListener[int] {
+		return func(ctx context.Context, v int) {
+			trace = append(trace, "outer")
+			next(context.WithValue(ctx, ctxKey{}, "outer"), v)
+		}
+	})
+	testSignal.Use(func(next signals.SignalListener[int]) signals.SignalListener[int] {
+		return func(ctx context.Context, v int) {
+			trace = append(trace, "inner")
+			if v < 0 {
+				return // Short-circuit the listener
+			}
+			next(ctx, v)
+		}
+	})
+
+	errListener := errors.New("listener")
+	testSignal.AddListener(func(ctx context.Context, v int) error {
+		trace = append(trace, ctx.Value(ctxKey{}).(string))
+		return errListener
+	})
+
+	ctx := context.Background()
+	require.ErrorIs(t, testSignal.Emit(ctx, 1), errListener)
+	require.Equal(t, []string{"outer", "inner", "outer"}, trace)
+
+	trace = trace[:0]
+	require.NoError(t, testSignal.Emit(ctx, -1))
+	require.Equal(t, []string{"outer", "inner"}, trace)
+}
+
+func TestSignalAsyncOrderedDelivery(t *testing.T) {
+	testSignal := signals.New[int](signals.WithOrderedDelivery())
+
+	const listeners = 4
+	var mu sync.Mutex
+	results := make([][]int, listeners)
+	for i := 0; i < listeners; i++ {
+		i := i
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			mu.Lock()
+			defer mu.Unlock()
+			results[i] = append(results[i], v)
+		})
+	}
+
+	ctx := context.Background()
+	expected := make([]int, 0, 1000)
+	for v := 1; v <= 1000; v++ {
+		require.NoError(t, testSignal.Emit(ctx, v))
+		expected = append(expected, v)
+	}
+	for i := 0; i < listeners; i++ {
+		require.Equal(t, expected, results[i])
+	}
+
+	// With concurrent emitters, all the listeners observe the same order.
+	results = make([][]int, listeners)
+	var wg sync.WaitGroup
+	for v := 1; v <= 200; v++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			assert.NoError(t, testSignal.Emit(ctx, v))
+		}(v)
+	}
+	wg.Wait()
+	for i := 1; i < listeners; i++ {
+		require.Equal(t, results[0], results[i])
+	}
+}
+
+func TestSignalAsyncOrderedReentrantEmit(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int](signals.WithOrderedDelivery())
+	other := signals.New[int](signals.WithOrderedDelivery())
+
+	var mu sync.Mutex
+	var errs []error
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		var err error
+		switch {
+		case v == 2:
+			err = testSignal.Emit(ctx, v-1)
+		case v == 3:
+			err = other.Emit(ctx, v)
+		default:
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	})
+	other.AddListener(func(ctx context.Context, v int) {
+		err := testSignal.Emit(ctx, v)
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, testSignal.Emit(ctx, 2))
+		assert.NoError(t, testSignal.Emit(ctx, 3))
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the re-entrant emission deadlocked")
+	}
+
+	require.Len(t, errs, 3)
+	require.ErrorIs(t, errs[0], signals.ErrReentrantEmit)
+	require.ErrorIs(t, errs[1], signals.ErrReentrantEmit)
+	require.NoError(t, errs[2], "the other signal delivered the value")
+
+	// Once the listener returned, its context can be used to emit again.
+	var saved context.Context
+	emitted := signals.New[int](signals.WithOrderedDelivery())
+	emitted.AddListener(func(ctx context.Context, v int) {
+		if v == 1 {
+			saved = ctx
+		}
+	})
+	require.NoError(t, emitted.Emit(ctx, 1))
+	require.NoError(t, emitted.Emit(saved, 2))
+}
+
+func TestSignalBuffer(t *testing.T) {
+	ctx := context.Background()
+
+	newBlocked := func(policy signals.OverflowPolicy) (signals.Signal[int], chan struct{}, *[]int, *sync.Mutex) {
+		testSignal := signals.New[int](signals.WithBuffer(2, policy))
+		release := make(chan struct{})
+		var mu sync.Mutex
+		results := make([]int, 0)
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			<-release
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, v)
+		})
+
+		// The first value is taken by the delivery goroutine, which blocks on
+		// the listener, so the next values stay in the buffer.
+		require.NoError(t, testSignal.Emit(ctx, 0))
+		require.Eventually(t, func() bool { return testSignal.QueueDepth() == 0 }, time.Second, time.Millisecond)
+		require.NoError(t, testSignal.Emit(ctx, 1))
+		require.NoError(t, testSignal.Emit(ctx, 2))
+		require.Equal(t, 2, testSignal.QueueDepth())
+
+		return testSignal, release, &results, &mu
+	}
+
+	t.Run("DropOldest", func(t *testing.T) {
+		testSignal, release, results, _ := newBlocked(signals.DropOldest)
+		require.ErrorIs(t, testSignal.Emit(ctx, 3), signals.ErrDropped)
+		require.Equal(t, 2, testSignal.QueueDepth())
+
+		close(release)
+		require.NoError(t, testSignal.Wait(ctx))
+		require.Equal(t, []int{0, 2, 3}, *results)
+	})
+
+	t.Run("DropNewest", func(t *testing.T) {
+		testSignal, release, results, _ := newBlocked(signals.DropNewest)
+		require.ErrorIs(t, testSignal.Emit(ctx, 3), signals.ErrDropped)
+
+		close(release)
+		require.NoError(t, testSignal.Wait(ctx))
+		require.Equal(t, []int{0, 1, 2}, *results)
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		testSignal, release, results, mu := newBlocked(signals.Block)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, testSignal.Emit(timeoutCtx, 3), context.DeadlineExceeded)
+
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+		}()
+		require.NoError(t, testSignal.Emit(ctx, 4))
+		require.NoError(t, testSignal.Wait(ctx))
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, []int{0, 1, 2, 4}, *results)
+	})
+
+	t.Run("DetachedContext", func(t *testing.T) {
+		testSignal := signals.NewSync[int](signals.WithBuffer(4, signals.Block))
+		var delivered atomic.Bool
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			delivered.Store(ctx.Err() == nil)
+		})
+
+		cancelled, cancel := context.WithCancel(ctx)
+		require.NoError(t, testSignal.Emit(cancelled, 1))
+		cancel()
+		require.NoError(t, testSignal.Wait(ctx))
+		require.True(t, delivered.Load())
+	})
+}
+
+type testObserver struct {
+	mu     sync.Mutex
+	emits  []int
+	starts []signals.SignalType
+	ends   []signals.SignalType
+	errs   []signals.SignalType
+}
+
+func (o *testObserver) OnEmit(n int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.emits = append(o.emits, n)
+}
+
+func (o *testObserver) OnListenerStart(key signals.SignalType) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.starts = append(o.starts, key)
+}
+
+func (o *testObserver) OnListenerEnd(key signals.SignalType, d time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.ends = append(o.ends, key)
+}
+
+func (o *testObserver) OnListenerError(key signals.SignalType, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.errs = append(o.errs, key)
+}
+
+func TestObserver(t *testing.T) {
+	ctx := context.Background()
+	obs := &testObserver{}
+	testSignal := signals.NewSync[int](signals.WithObserver(obs))
+
+	testSignal.AddListener(func(ctx context.Context, v int) {}, signals.SignalType(1))
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		if v == 2 {
+			panic("boom")
+		}
+	}, signals.SignalType(2))
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Error(t, testSignal.Emit(ctx, 2))
+
+	require.Equal(t, []int{2, 2}, obs.emits)
+	require.Equal(t, []signals.SignalType{1, 2, 1, 2}, obs.starts)
+	require.Equal(t, []signals.SignalType{1, 2, 1, 2}, obs.ends)
+	require.Equal(t, []signals.SignalType{2}, obs.errs)
+}
+
+type testQueueObserver struct {
+	testObserver
+	waits []time.Duration
+}
+
+func (o *testQueueObserver) OnListenerQueued(key signals.SignalType, wait time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.waits = append(o.waits, wait)
+}
+
+func TestObserverDurations(t *testing.T) {
+	ctx := context.Background()
+	obs := &testQueueObserver{}
+	testSignal := signals.New[int](signals.WithObserver(obs), signals.WithMaxConcurrency(1))
+
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		time.Sleep(10 * time.Millisecond)
+	})
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.NoError(t, testSignal.Emit(ctx, 2))
+
+	// The anonymous listeners keep the same generated keys across emissions.
+	require.Len(t, obs.starts, 4)
+	require.Less(t, obs.starts[0], signals.SignalType(0))
+	require.ElementsMatch(t, obs.starts[:2], obs.starts[2:])
+	require.NotEqual(t, obs.starts[0], obs.starts[1])
+
+	// With a single slot, one of the invocations of each emission waited for
+	// the other one to finish.
+	require.Len(t, obs.waits, 4)
+	require.GreaterOrEqual(t, max(obs.waits[0], obs.waits[1]), 10*time.Millisecond)
+}
+
+func TestPauseResume(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Drop", func(t *testing.T) {
+		testSignal := signals.NewSync[int]()
+		results := make([]int, 0)
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			results = append(results, v)
+		})
+
+		testSignal.Pause()
+		require.True(t, testSignal.IsPaused())
+		require.Equal(t, 1, testSignal.Len())
+		require.ErrorIs(t, testSignal.Emit(ctx, 1), signals.ErrPaused)
+
+		testSignal.Resume()
+		require.False(t, testSignal.IsPaused())
+		require.NoError(t, testSignal.Emit(ctx, 2))
+		require.Equal(t, []int{2}, results)
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		testSignal := signals.New[int](signals.WithPausePolicy(signals.PauseReplay), signals.WithOrderedDelivery())
+		var mu sync.Mutex
+		results := make([]int, 0)
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, v)
+		})
+
+		testSignal.Pause()
+		for i := 1; i <= 3; i++ {
+			require.NoError(t, testSignal.Emit(ctx, i))
+		}
+		require.Empty(t, results)
+
+		testSignal.Resume()
+		require.NoError(t, testSignal.Emit(ctx, 4))
+		require.Equal(t, []int{1, 2, 3, 4}, results)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		testSignal := signals.New[int](signals.WithPausePolicy(signals.PauseReplay))
+		var count atomic.Int64
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			count.Add(1)
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, testSignal.Emit(ctx, 1))
+			}()
+			go func() {
+				defer wg.Done()
+				testSignal.Pause()
+				testSignal.Resume()
+			}()
+		}
+		wg.Wait()
+
+		require.False(t, testSignal.IsPaused())
+		require.EqualValues(t, 50, count.Load())
+	})
+}
+
+func TestEmitAndWait(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int]()
+	var count atomic.Int64
+	release := make(chan struct{})
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		count.Add(1)
+	})
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		if v == 2 {
+			<-release
+		}
+		count.Add(1)
+	}, signals.SignalType(2))
+
+	require.NoError(t, testSignal.EmitAndWait(ctx, 1))
+	require.EqualValues(t, 2, count.Load())
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	err := testSignal.EmitAndWait(timeoutCtx, 2)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorIs(t, err, signals.ErrPartialDelivery)
+
+	var partial *signals.PartialDeliveryError
+	require.ErrorAs(t, err, &partial)
+	require.Equal(t, 1, partial.Finished)
+	require.Equal(t, []signals.SignalType{2}, partial.Pending)
+
+	close(release)
+	require.NoError(t, testSignal.Wait(ctx))
+	require.EqualValues(t, 4, count.Load())
+}
+
+func TestAddListenerWithID(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int]()
+	results := make([]string, 0)
+
+	first, count := testSignal.AddListenerWithID(func(ctx context.Context, v int) {
+		results = append(results, "first")
+	})
+	require.Equal(t, 1, count)
+	second, count := testSignal.AddListenerWithID(func(ctx context.Context, v int) {
+		results = append(results, "second")
+	})
+	require.Equal(t, 2, count)
+	keyed, count := testSignal.AddListenerWithID(func(ctx context.Context, v int) {
+		results = append(results, "keyed")
+	}, signals.SignalType(1))
+	require.Equal(t, 3, count)
+	require.Equal(t, signals.ListenerID(1), keyed)
+	require.NotEqual(t, first, second)
+
+	_, count = testSignal.AddListenerWithID(func(ctx context.Context, v int) {}, signals.SignalType(1))
+	require.Equal(t, -1, count)
+
+	require.True(t, testSignal.HasListener(second))
+	require.Equal(t, 2, testSignal.RemoveListener(second))
+	require.Equal(t, -1, testSignal.RemoveListener(second))
+	require.Equal(t, []signals.SignalType{1}, testSignal.Keys())
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, []string{"first", "keyed"}, results)
+}
+
+func TestNegativeKeys(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int]()
+	results := make([]string, 0)
+
+	for _, key := range []signals.SignalType{-1, -2, -3} {
+		require.Positive(t, testSignal.AddListener(func(ctx context.Context, v int) {
+			results = append(results, "keyed")
+		}, key))
+	}
+	require.Equal(t, 4, testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, "anonymous")
+	}))
+
+	id, count := testSignal.AddListenerWithID(func(ctx context.Context, v int) {})
+	require.Equal(t, 5, count)
+	require.Less(t, id, signals.SignalType(-3))
+
+	// A key chosen by hand that the next generated key would take is skipped.
+	require.Equal(t, 6, testSignal.AddListener(func(ctx context.Context, v int) {}, id+1))
+	next, count := testSignal.AddListenerWithID(func(ctx context.Context, v int) {})
+	require.Equal(t, 7, count)
+	require.NotEqual(t, id+1, next)
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, []string{"keyed", "keyed", "keyed", "anonymous"}, results)
+	require.Equal(t, 6, testSignal.RemoveListener(-3))
+	require.True(t, testSignal.HasListener(next))
+}
+
+func TestOn(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int]()
+	results := make([]string, 0)
+
+	off := testSignal.On(func(ctx context.Context, v int) {
+		results = append(results, "first")
+	}, signals.SignalType(1))
+	testSignal.On(func(ctx context.Context, v int) {
+		results = append(results, "anonymous")
+	})
+	require.Equal(t, 2, testSignal.Len())
+
+	off()
+	off()
+	require.Equal(t, 1, testSignal.Len())
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, []string{"anonymous"}, results)
+
+	// After Reset, a stale function does not remove the listener that reuses
+	// the key.
+	testSignal.Reset()
+	off()
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, "second")
+	}, signals.SignalType(1))
+	off()
+	require.True(t, testSignal.HasListener(1))
+
+	// A duplicate key is rejected and its function does nothing.
+	testSignal.On(func(ctx context.Context, v int) {}, signals.SignalType(1))()
+	require.True(t, testSignal.HasListener(1))
+}
+
+func TestTryEmit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Buffer", func(t *testing.T) {
+		testSignal := signals.New[int](signals.WithBuffer(1, signals.Block)).(*signals.AsyncSignal[int])
+		release := make(chan struct{})
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			<-release
+		})
+
+		ok, err := testSignal.TryEmit(ctx, 1)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Eventually(t, func() bool { return testSignal.QueueDepth() == 0 }, time.Second, time.Millisecond)
+
+		ok, err = testSignal.TryEmit(ctx, 2)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = testSignal.TryEmit(ctx, 3)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Equal(t, 1, testSignal.QueueDepth())
+
+		close(release)
+		require.NoError(t, testSignal.Wait(ctx))
+	})
+
+	t.Run("MaxConcurrency", func(t *testing.T) {
+		testSignal := signals.New[int](signals.WithMaxConcurrency(1)).(*signals.AsyncSignal[int])
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var count atomic.Int64
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			count.Add(1)
+			if v == 1 {
+				close(started)
+				<-release
+			}
+		})
+
+		go func() {
+			assert.NoError(t, testSignal.Emit(ctx, 1))
+		}()
+		<-started
+
+		ok, err := testSignal.TryEmit(ctx, 2)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		close(release)
+		require.NoError(t, testSignal.Wait(ctx))
+		ok, err = testSignal.TryEmit(ctx, 3)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.EqualValues(t, 2, count.Load())
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		testSignal := signals.NewSync[int]().(*signals.SyncSignal[int])
+		ok, err := testSignal.TryEmit(ctx, 1)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		testSignal.Close()
+		ok, err = testSignal.TryEmit(ctx, 2)
+		require.ErrorIs(t, err, signals.ErrSignalClosed)
+		require.False(t, ok)
+	})
+}
+
+func TestEmitBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sync", func(t *testing.T) {
+		testSignal := signals.NewSync[int]()
+		results := make([]string, 0)
+		batches := make([][]int, 0)
+		testSignal.AddListenerWith(func(ctx context.Context, v int) {
+			results = append(results, fmt.Sprintf("single %d", v))
+		}, signals.WithPriority(1))
+		testSignal.AddBatchListener(func(ctx context.Context, values []int) {
+			batches = append(batches, values)
+		})
+		testSignal.AddListenerFiltered(func(ctx context.Context, v int) {
+			results = append(results, fmt.Sprintf("even %d", v))
+		}, func(v int) bool { return v%2 == 0 })
+
+		require.NoError(t, testSignal.EmitBatch(ctx, []int{1, 2, 3, 4}))
+		require.NoError(t, testSignal.Emit(ctx, 5))
+		require.NoError(t, testSignal.EmitBatch(ctx, nil))
+
+		require.Equal(t, []string{"single 1", "single 2", "single 3", "single 4", "even 2", "even 4", "single 5"}, results)
+		require.Equal(t, [][]int{{1, 2, 3, 4}, {5}}, batches)
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		testSignal := signals.New[int]()
+		var mu sync.Mutex
+		results := make([]int, 0)
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, v)
+		})
+		testSignal.AddBatchListener(func(ctx context.Context, values []int) {
+			panic("boom")
+		})
+
+		err := testSignal.EmitBatch(ctx, []int{1, 2, 3})
+		var panicErr *signals.PanicError
+		require.ErrorAs(t, err, &panicErr)
+		require.Equal(t, []int{1, 2, 3}, results)
+	})
+}
+
+func TestClone(t *testing.T) {
+	ctx := context.Background()
+	source := signals.NewSync[int]()
+	results := make([]string, 0)
+	source.AddListener(func(ctx context.Context, v int) {
+		results = append(results, fmt.Sprintf("common %d", v))
+	}, signals.SignalType(1))
+
+	clone := source.Clone()
+	require.Equal(t, []signals.SignalType{1}, clone.Keys())
+
+	clone.AddListener(func(ctx context.Context, v int) {
+		results = append(results, fmt.Sprintf("scoped %d", v))
+	}, signals.SignalType(2))
+	require.Equal(t, 1, source.Len())
+	require.Equal(t, 2, clone.Len())
+
+	require.NoError(t, clone.Emit(ctx, 1))
+	require.NoError(t, source.Emit(ctx, 2))
+	require.Equal(t, []string{"common 1", "scoped 1", "common 2"}, results)
+
+	clone.Reset()
+	clone.Close()
+	require.Equal(t, 1, source.Len())
+	require.NoError(t, source.Emit(ctx, 3))
+
+	asyncClone := signals.New[int](signals.WithMaxConcurrency(1)).Clone()
+	_, ok := asyncClone.(*signals.AsyncSignal[int])
+	require.True(t, ok)
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	errTransient := errors.New("transient")
+
+	t.Run("Succeeds", func(t *testing.T) {
+		backoffs := make([]int, 0)
+		testSignal := signals.NewResult[int](signals.WithRetry(3, func(attempt int) time.Duration {
+			backoffs = append(backoffs, attempt)
+			return time.Millisecond
+		}))
+		calls := 0
+		testSignal.AddListener(func(ctx context.Context, v int) error {
+			calls++
+			if calls < 3 {
+				return errTransient
+			}
+			return nil
+		})
+
+		require.NoError(t, testSignal.Emit(ctx, 1))
+		require.Equal(t, 3, calls)
+		require.Equal(t, []int{1, 2}, backoffs)
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		testSignal := signals.NewResult[int](signals.WithRetry(2, nil))
+		calls := 0
+		testSignal.AddListener(func(ctx context.Context, v int) error {
+			calls++
+			return fmt.Errorf("attempt %d: %w", calls, errTransient)
+		})
+
+		err := testSignal.Emit(ctx, 1)
+		require.ErrorIs(t, err, errTransient)
+		require.EqualError(t, err, "attempt 3: transient")
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		testSignal := signals.NewResult[int](signals.WithRetry(5, func(attempt int) time.Duration {
+			return time.Hour
+		}))
+		calls := 0
+		testSignal.AddListener(func(ctx context.Context, v int) error {
+			calls++
+			return errTransient
+		})
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, testSignal.Emit(timeoutCtx, 1), errTransient)
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestAddListenerCtx(t *testing.T) {
+	testSignal := signals.NewSync[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	require.Equal(t, 1, testSignal.AddListenerCtx(ctx, func(ctx context.Context, v int) {}, signals.SignalType(1)))
+	testSignal.AddListener(func(ctx context.Context, v int) {})
+	require.Equal(t, 2, testSignal.Len())
+
+	cancel()
+	require.Eventually(t, func() bool { return !testSignal.HasListener(1) }, time.Second, time.Millisecond)
+	require.Equal(t, 1, testSignal.Len())
+
+	// A listener removed before its context is done is not removed again.
+	ctx, cancel = context.WithCancel(context.Background())
+	testSignal.AddListenerCtx(ctx, func(ctx context.Context, v int) {}, signals.SignalType(2))
+	testSignal.RemoveListener(2)
+	testSignal.AddListener(func(ctx context.Context, v int) {}, signals.SignalType(2))
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	require.True(t, testSignal.HasListener(2))
+}
+
+func TestAddListenerCtxNotInvokedOnceDone(t *testing.T) {
+	t.Run("DuringEmission", func(t *testing.T) {
+		testSignal := signals.NewSync[int]()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// The first listener cancels the context of the second one, which is
+		// still in the snapshot of the emission but must be skipped.
+		testSignal.AddListenerWith(func(context.Context, int) { cancel() }, signals.WithPriority(1))
+		called := false
+		testSignal.AddListenerCtx(ctx, func(context.Context, int) { called = true })
+
+		require.NoError(t, testSignal.Emit(context.Background(), 1))
+		require.False(t, called)
+		require.Eventually(t, func() bool { return testSignal.Len() == 1 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		testSignal := signals.NewSync[int](signals.WithReplay())
+		require.NoError(t, testSignal.Emit(context.Background(), 1))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		called := false
+		testSignal.AddListenerCtx(ctx, func(context.Context, int) { called = true })
+		require.False(t, called)
+	})
+
+	t.Run("EmitContext", func(t *testing.T) {
+		// The cancellation of the context of an emission does not remove a
+		// bound listener.
+		testSignal := signals.NewSync[int]()
+		calls := 0
+		testSignal.AddListenerCtx(context.Background(), func(context.Context, int) { calls++ })
+
+		emitCtx, cancel := context.WithCancel(context.Background())
+		require.NoError(t, testSignal.Emit(emitCtx, 1))
+		cancel()
+		require.NoError(t, testSignal.Emit(context.Background(), 2))
+		require.Equal(t, 2, calls)
+	})
+}
+
+type testHandler struct {
+	name    string
+	results *[]string
+}
+
+func (h *testHandler) OnSignal(ctx context.Context, v int) {
+	*h.results = append(*h.results, fmt.Sprintf("%s %d", h.name, v))
+}
+
+func TestAddHandler(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int]()
+	results := make([]string, 0)
+	first := &testHandler{name: "first", results: &results}
+	second := &testHandler{name: "second", results: &results}
+
+	require.Equal(t, 1, testSignal.AddHandler(first))
+	require.Equal(t, 2, testSignal.AddHandler(second, signals.SignalType(1)))
+	require.Equal(t, -1, testSignal.AddHandler(first))
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, []string{"first 1", "second 1"}, results)
+
+	require.Equal(t, 1, testSignal.RemoveHandler(first))
+	require.Equal(t, -1, testSignal.RemoveHandler(first))
+	require.Equal(t, 0, testSignal.RemoveListener(1))
+	require.Equal(t, -1, testSignal.RemoveHandler(second))
+	require.Equal(t, 1, testSignal.AddHandler(first))
+}
+
+func TestMaxListeners(t *testing.T) {
+	testSignal := signals.New[int](signals.WithMaxListeners(2))
+	require.Equal(t, 1, testSignal.AddListener(func(ctx context.Context, v int) {}, signals.SignalType(1)))
+	require.Equal(t, 2, testSignal.AddListener(func(ctx context.Context, v int) {}))
+	require.Equal(t, signals.ListenerLimitReached, testSignal.AddListener(func(ctx context.Context, v int) {}))
+	require.Equal(t, -1, testSignal.AddListener(func(ctx context.Context, v int) {}, signals.SignalType(1)))
+	require.Equal(t, 2, testSignal.Len())
+
+	testSignal.RemoveListener(1)
+	require.Equal(t, 2, testSignal.AddListenerOnce(func(ctx context.Context, v int) {}))
+
+	collector := signals.NewCollector[int, int](signals.WithMaxListeners(1))
+	require.Equal(t, 1, collector.AddListener(func(ctx context.Context, v int) int { return v }))
+	require.Equal(t, signals.ListenerLimitReached, collector.AddListener(func(ctx context.Context, v int) int { return v }))
+}
+
+func TestStoppableSignal(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewStoppable[int]()
+	results := make([]string, 0)
+
+	testSignal.AddListener(func(ctx context.Context, v int) bool {
+		results = append(results, "low")
+		return true
+	})
+	testSignal.AddListener(func(ctx context.Context, v int) bool {
+		results = append(results, "high")
+		return v%2 == 0
+	}, signals.WithPriority(10))
+	testSignal.AddListenerFiltered(func(ctx context.Context, v int) {
+		results = append(results, "filtered")
+	}, func(v int) bool { return v > 0 }, signals.WithPriority(5))
+
+	ran, err := testSignal.Emit(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, ran)
+	require.Equal(t, []string{"high"}, results)
+
+	results = results[:0]
+	ran, err = testSignal.Emit(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, ran)
+	require.Equal(t, []string{"high", "filtered", "low"}, results)
+
+	results = results[:0]
+	ran, err = testSignal.Emit(ctx, -2)
+	require.NoError(t, err)
+	require.Equal(t, 2, ran)
+	require.Equal(t, []string{"high", "low"}, results)
+}
+
+func TestEmitTagged(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int]()
+	results := make([]string, 0)
+	testSignal.AddListenerWith(func(ctx context.Context, v int) {
+		results = append(results, "audit")
+	}, signals.WithTags("audit"))
+	testSignal.AddListenerWith(func(ctx context.Context, v int) {
+		results = append(results, "both")
+	}, signals.WithTags("audit", "metrics"))
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, "untagged")
+	})
+
+	require.NoError(t, testSignal.EmitTagged(ctx, 1, "metrics"))
+	require.Equal(t, []string{"both"}, results)
+
+	results = results[:0]
+	require.NoError(t, testSignal.EmitTagged(ctx, 1, "audit", "other"))
+	require.Equal(t, []string{"audit", "both"}, results)
+
+	results = results[:0]
+	require.NoError(t, testSignal.EmitTagged(ctx, 1))
+	require.Empty(t, results)
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, []string{"audit", "both", "untagged"}, results)
+}
+
+func TestRemoveGroup(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int]()
+	var count atomic.Int64
+	listener := func(ctx context.Context, v int) { count.Add(1) }
+	testSignal.AddListenerWith(listener, signals.WithTags("audit"), signals.SignalType(1))
+	testSignal.AddListenerWith(listener, signals.WithTags("audit", "metrics"))
+	testSignal.AddListenerWith(listener, signals.WithTags("metrics"))
+	testSignal.AddListener(listener)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, testSignal.Emit(ctx, i))
+		}
+	}()
+
+	require.Equal(t, 2, testSignal.RemoveGroup("audit"))
+	require.Equal(t, 0, testSignal.RemoveGroup("audit"))
+	require.False(t, testSignal.HasListener(1))
+	require.Equal(t, 2, testSignal.Len())
+
+	require.Equal(t, 1, testSignal.RemoveGroup("metrics"))
+	require.Equal(t, 1, testSignal.Len())
+	wg.Wait()
+}
+
+func TestEmitSnapshot(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int]()
+	results := make([]string, 0)
+
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, fmt.Sprintf("first %d", v))
+		if v == 1 {
+			testSignal.AddListener(func(ctx context.Context, v int) {
+				results = append(results, fmt.Sprintf("added %d", v))
+			})
+			testSignal.RemoveListener(2)
+		}
+	})
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, fmt.Sprintf("removed %d", v))
+	}, signals.SignalType(2))
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, []string{"first 1", "removed 1"}, results)
+
+	results = results[:0]
+	require.NoError(t, testSignal.Emit(ctx, 2))
+	require.Equal(t, []string{"first 2", "added 2"}, results)
+}
+
+func TestRateLimit(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int](signals.WithRateLimit(10, 2)).(*signals.AsyncSignal[int])
+	var count atomic.Int64
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		count.Add(1)
+	})
+
+	var wg sync.WaitGroup
+	var limited atomic.Int64
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if errors.Is(testSignal.Emit(ctx, 1), signals.ErrRateLimited) {
+				limited.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 2, count.Load())
+	require.EqualValues(t, 3, limited.Load())
+
+	ok, err := testSignal.TryEmit(ctx, 1)
+	require.ErrorIs(t, err, signals.ErrRateLimited)
+	require.False(t, ok)
+
+	require.Eventually(t, func() bool { return testSignal.Emit(ctx, 1) == nil }, time.Second, 5*time.Millisecond)
+	require.EqualValues(t, 3, count.Load())
+}
+
+func TestWaitFor(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int]()
+
+	go func() {
+		for i := 1; i <= 5; i++ {
+			time.Sleep(time.Millisecond)
+			assert.NoError(t, testSignal.Emit(ctx, i))
+		}
+	}()
+
+	v, err := testSignal.WaitFor(ctx, func(v int) bool { return v >= 3 })
+	require.NoError(t, err)
+	require.Equal(t, 3, v)
+	require.True(t, testSignal.IsEmpty())
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	v, err = testSignal.WaitFor(timeoutCtx, func(v int) bool { return v > 100 })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Zero(t, v)
+	require.True(t, testSignal.IsEmpty())
+
+	full := signals.New[int](signals.WithMaxListeners(1))
+	full.AddListener(func(ctx context.Context, v int) {})
+	_, err = full.WaitFor(ctx, nil)
+	require.ErrorIs(t, err, signals.ErrListenerRefused)
+	require.Equal(t, 1, full.Len())
+}
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int](signals.WithHistory(3))
+	require.Empty(t, testSignal.History())
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.NoError(t, testSignal.Emit(ctx, 2))
+	require.Equal(t, []int{1, 2}, testSignal.History())
+
+	require.NoError(t, testSignal.EmitBatch(ctx, []int{3, 4}))
+	require.NoError(t, testSignal.Emit(ctx, 5))
+	require.Equal(t, []int{3, 4, 5}, testSignal.History())
+	require.Equal(t, []int{3, 4, 5}, testSignal.Clone().History())
+
+	testSignal.Reset()
+	require.Empty(t, testSignal.History())
+	require.Empty(t, signals.New[int]().History())
+}
+
+func TestDistinct(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewDistinct[int](signals.WithHistory(10)).(*signals.SyncSignal[int])
+	results := make([]int, 0)
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, v)
+	})
+
+	require.NoError(t, testSignal.Emit(ctx, 0))
+	require.NoError(t, testSignal.Emit(ctx, 0))
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.NoError(t, testSignal.EmitBatch(ctx, []int{1, 2, 2, 3}))
+	require.NoError(t, testSignal.EmitBatch(ctx, []int{3, 3}))
+	ok, err := testSignal.TryEmit(ctx, 3)
+	require.True(t, ok)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2, 3}, results)
+	require.Equal(t, []int{0, 1, 2, 3}, testSignal.History())
+
+	testSignal.Reset()
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, v)
+	})
+	require.NoError(t, testSignal.Emit(ctx, 3))
+	require.Equal(t, []int{0, 1, 2, 3, 3}, results)
+
+	t.Run("WithEquality", func(t *testing.T) {
+		testSignal := signals.New[[]int](signals.WithEquality(func(a, b []int) bool {
+			return slices.Equal(a, b)
+		}))
+		var calls atomic.Int32
+		testSignal.AddListener(func(ctx context.Context, v []int) {
+			calls.Add(1)
+		})
+
+		require.NoError(t, testSignal.Emit(ctx, []int{1}))
+		require.NoError(t, testSignal.Emit(ctx, []int{1}))
+		require.NoError(t, testSignal.Emit(ctx, []int{1, 2}))
+		require.Equal(t, int32(2), calls.Load())
+	})
+}
+
+func TestEmitN(t *testing.T) {
+	type countingSignal interface {
+		signals.Signal[int]
+		EmitN(ctx context.Context, payload int) (int, error)
+	}
+
+	ctx := context.Background()
+	for name, testSignal := range map[string]countingSignal{
+		"Sync":  signals.NewSync[int]().(*signals.SyncSignal[int]),
+		"Async": signals.New[int]().(*signals.AsyncSignal[int]),
+	} {
+		testSignal := testSignal
+		t.Run(name, func(t *testing.T) {
+			n, err := testSignal.EmitN(ctx, 1)
+			require.NoError(t, err)
+			require.Equal(t, 0, n)
+
+			testSignal.AddListener(func(ctx context.Context, v int) {})
+			testSignal.AddListenerWith(func(ctx context.Context, v int) {}, signals.WithTags("audit"))
+			testSignal.AddListenerFiltered(func(ctx context.Context, v int) {}, func(v int) bool {
+				return v > 1
+			})
+			n, err = testSignal.EmitN(ctx, 1)
+			require.NoError(t, err)
+			require.Equal(t, 2, n)
+
+			n, err = testSignal.EmitN(ctx, 2)
+			require.NoError(t, err)
+			require.Equal(t, 3, n)
+
+			testSignal.Close()
+			n, err = testSignal.EmitN(ctx, 2)
+			require.ErrorIs(t, err, signals.ErrSignalClosed)
+			require.Equal(t, 0, n)
+		})
+	}
+
+	throttled := signals.NewThrottled[int](time.Hour)
+	throttled.AddListener(func(ctx context.Context, v int) {})
+	n, err := throttled.EmitN(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	n, err = throttled.EmitN(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+	throttled.Close()
+}
+
+func TestSyncSignalReentrantEmit(t *testing.T) {
+	testSignal := signals.NewSync[int](signals.WithReplay(), signals.WithHistory(10))
+	results := make([]string, 0)
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, fmt.Sprintf("first %d", v))
+		if v > 0 {
+			assert.NoError(t, testSignal.Emit(ctx, v-1))
+		}
+	})
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, fmt.Sprintf("second %d", v))
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, testSignal.Emit(context.Background(), 2))
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reentrant emit deadlocked")
+	}
+
+	// Each nested emission completes before the outer one continues.
+	require.Equal(t, []string{
+		"first 2", "first 1", "first 0", "second 0", "second 1", "second 2",
+	}, results)
+	require.Equal(t, []int{2, 1, 0}, testSignal.History())
+}
+
+func TestRegistry(t *testing.T) {
+	created := signals.New[string]()
+	signals.Register("test.created", created)
+	defer signals.Unregister("test.created")
+
+	got, ok := signals.Get[string]("test.created")
+	require.True(t, ok)
+	require.Same(t, created, got)
+
+	_, ok = signals.Get[int]("test.created")
+	require.False(t, ok)
+	_, ok = signals.Get[string]("test.missing")
+	require.False(t, ok)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("test.concurrent.%d", i)
+			signals.Register(name, signals.NewSync[int]())
+			_, ok := signals.Get[int](name)
+			assert.True(t, ok)
+			signals.Unregister(name)
+		}(i)
+	}
+	wg.Wait()
+
+	signals.Unregister("test.created")
+	_, ok = signals.Get[string]("test.created")
+	require.False(t, ok)
+}
+
+func TestSerial(t *testing.T) {
+	testSignal := signals.NewSerial[int]()
+
+	// The listeners use no locking: the race detector reports any concurrent
+	// invocation.
+	results := make([]int, 0)
+	sum := 0
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, v)
+	})
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		sum += v
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				assert.NoError(t, testSignal.Emit(context.Background(), g*100+i))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	testSignal.Close()
+	require.ErrorIs(t, testSignal.Emit(context.Background(), 0), signals.ErrSignalClosed)
+	require.NoError(t, testSignal.Wait(context.Background()))
+	require.Len(t, results, 200)
+	require.Equal(t, 4*1225+100*50*(0+1+2+3), sum)
+
+	// The values of each goroutine are delivered in the order they were
+	// emitted.
+	last := map[int]int{0: -1, 1: -1, 2: -1, 3: -1}
+	for _, v := range results {
+		require.Greater(t, v%100, last[v/100])
+		last[v/100] = v % 100
+	}
+}
+
+func TestEmitFunc(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int]().(*signals.AsyncSignal[int])
+	produced := 0
+	produce := func() int {
+		produced++
+		return produced
+	}
+
+	require.NoError(t, testSignal.EmitFunc(ctx, produce))
+	require.Equal(t, 0, produced)
+
+	var received atomic.Int32
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		received.Store(int32(v))
+	})
+	require.NoError(t, testSignal.EmitFunc(ctx, produce))
+	require.Equal(t, 1, produced)
+	require.Equal(t, int32(1), received.Load())
+
+	testSignal.Close()
+	require.ErrorIs(t, testSignal.EmitFunc(ctx, produce), signals.ErrSignalClosed)
+	require.Equal(t, 1, produced)
+
+	debounced := signals.NewDebounced[int](time.Millisecond)
+	require.NoError(t, debounced.EmitFunc(ctx, produce))
+	require.Equal(t, 1, produced)
+	debounced.Close()
+	require.ErrorIs(t, debounced.EmitFunc(ctx, produce), signals.ErrSignalClosed)
+}
+
+func TestOutcomeCallback(t *testing.T) {
+	var mu sync.Mutex
+	outcomes := make(map[signals.SignalType]signals.Outcome)
+	testSignal := signals.NewResult[int](signals.WithOutcomeCallback(func(key signals.SignalType, o signals.Outcome) {
+		mu.Lock()
+		defer mu.Unlock()
+		outcomes[key] = o
+	}))
+
+	errFailed := errors.New("failed")
+	testSignal.AddListener(func(ctx context.Context, v int) error { return nil }, signals.SignalType(1))
+	testSignal.AddListener(func(ctx context.Context, v int) error { return errFailed }, signals.SignalType(2))
+	testSignal.AddListener(func(ctx context.Context, v int) error { panic("boom") }, signals.SignalType(3))
+	testSignal.AddListener(func(ctx context.Context, v int) error { return nil }, signals.SignalType(4), signals.WithTags("audit"))
+
+	require.NoError(t, testSignal.EmitTagged(context.Background(), 1, "audit"))
+	require.Equal(t, signals.OutcomeSkipped, outcomes[1].Status)
+	require.Equal(t, signals.OutcomeRan, outcomes[4].Status)
+
+	require.Error(t, testSignal.Emit(context.Background(), 1))
+	require.Equal(t, signals.Outcome{Status: signals.OutcomeRan}, outcomes[1])
+	require.Equal(t, signals.OutcomeFailed, outcomes[2].Status)
+	require.ErrorIs(t, outcomes[2].Err, errFailed)
+	require.Equal(t, signals.OutcomePanicked, outcomes[3].Status)
+	require.Equal(t, "boom", outcomes[3].Recovered)
+	require.Equal(t, "panicked", outcomes[3].Status.String())
+
+	t.Run("Async", func(t *testing.T) {
+		var skipped, ran atomic.Int32
+		testSignal := signals.New[int](signals.WithOutcomeCallback(func(key signals.SignalType, o signals.Outcome) {
+			switch o.Status {
+			case signals.OutcomeSkipped:
+				skipped.Add(1)
+			case signals.OutcomeRan:
+				ran.Add(1)
+			}
+		}))
+		testSignal.AddListener(func(ctx context.Context, v int) {})
+		testSignal.AddListenerFiltered(func(ctx context.Context, v int) {}, func(v int) bool { return v > 1 })
+		testSignal.AddListenerOnce(func(ctx context.Context, v int) {})
+
+		require.NoError(t, testSignal.Emit(context.Background(), 1))
+		require.Equal(t, int32(2), ran.Load())
+		require.Equal(t, int32(1), skipped.Load())
+	})
+}
+
+func TestReplaceListener(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int]()
+	results := make([]string, 0)
+	record := func(name string) signals.SignalListener[int] {
+		return func(ctx context.Context, v int) {
+			results = append(results, name)
+		}
+	}
+
+	require.False(t, testSignal.ReplaceListener(record("first"), 1, signals.WithPriority(10)))
+	testSignal.AddListener(record("other"))
+	require.True(t, testSignal.ReplaceListener(record("second"), 1, signals.WithPriority(-10)))
+	require.Equal(t, 2, testSignal.Len())
+
+	// The replaced listener keeps its priority.
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, []string{"second", "other"}, results)
+
+	require.Equal(t, 1, testSignal.RemoveListener(1))
+
+	t.Run("Concurrent", func(t *testing.T) {
+		testSignal := signals.New[int]()
+		var calls atomic.Int32
+		listener := func(ctx context.Context, v int) {
+			calls.Add(1)
+		}
+		testSignal.AddListener(listener, signals.SignalType(1))
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.NoError(t, testSignal.Emit(ctx, i))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.True(t, testSignal.ReplaceListener(listener, 1))
+			}
+		}()
+		wg.Wait()
+
+		require.Equal(t, int32(100), calls.Load())
+	})
+}
+
+func TestEmitFactory(t *testing.T) {
+	type order struct {
+		items []string
+	}
+
+	testSignal := signals.New[*order](signals.WithReplay()).(*signals.AsyncSignal[*order])
+	var mu sync.Mutex
+	seen := make(map[*order]bool)
+	for i := 0; i < 3; i++ {
+		testSignal.AddListener(func(ctx context.Context, o *order) {
+			// The listeners modify the value without locking it.
+			o.items = append(o.items, "listener")
+
+			mu.Lock()
+			defer mu.Unlock()
+			seen[o] = true
+		})
+	}
+	testSignal.AddListenerFiltered(func(ctx context.Context, o *order) {
+		t.Error("the filter must reject the value")
+	}, func(o *order) bool {
+		return len(o.items) == 0
+	})
+
+	var built atomic.Int32
+	require.NoError(t, testSignal.EmitFactory(context.Background(), func() *order {
+		built.Add(1)
+		return &order{items: []string{"base"}}
+	}))
+	require.Len(t, seen, 3)
+	require.Equal(t, int32(4), built.Load())
+
+	// The value built for the filters is the one replayed.
+	var replayed *order
+	testSignal.AddListener(func(ctx context.Context, o *order) {
+		replayed = o
+	})
+	require.Equal(t, []string{"base"}, replayed.items)
+	require.False(t, seen[replayed])
+
+	// The factory is not called for an emission the signal refuses.
+	testSignal.Close()
+	require.ErrorIs(t, testSignal.EmitFactory(context.Background(), func() *order {
+		t.Error("the factory must not be called")
+		return nil
+	}), signals.ErrSignalClosed)
+}
+
+func TestRemoveListenerAndWait(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int]()
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var finished atomic.Bool
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		started <- struct{}{}
+		<-release
+		finished.Store(true)
+	}, signals.SignalType(1))
+	testSignal.AddListener(func(ctx context.Context, v int) {}, signals.SignalType(2))
+
+	go func() {
+		assert.NoError(t, testSignal.Emit(ctx, 1))
+	}()
+	<-started
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	count, err := testSignal.RemoveListenerAndWait(timeoutCtx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 1, count)
+	require.False(t, testSignal.HasListener(1))
+	require.False(t, finished.Load())
+
+	close(release)
+	require.NoError(t, testSignal.Wait(ctx))
+	require.True(t, finished.Load())
+
+	// Once the wait is not interrupted, the listener has returned when
+	// RemoveListenerAndWait returns.
+	release = make(chan struct{})
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		started <- struct{}{}
+		<-release
+		finished.Store(false)
+	}, signals.SignalType(3))
+	go func() {
+		assert.NoError(t, testSignal.Emit(ctx, 2))
+	}()
+	<-started
+	time.AfterFunc(10*time.Millisecond, func() { close(release) })
+	count, err = testSignal.RemoveListenerAndWait(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	require.False(t, finished.Load())
+
+	count, err = testSignal.RemoveListenerAndWait(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, -1, count)
+
+	t.Run("SkippedOnceRemoved", func(t *testing.T) {
+		testSignal := signals.NewSync[int]()
+		called := false
+		testSignal.AddListenerWith(func(ctx context.Context, v int) {
+			_, err := testSignal.RemoveListenerAndWait(ctx, 1)
+			assert.NoError(t, err)
+		}, signals.WithPriority(1))
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			called = true
+		}, signals.SignalType(1))
+
+		require.NoError(t, testSignal.Emit(ctx, 1))
+		require.False(t, called)
+	})
+}
+
+func TestGate(t *testing.T) {
+	ctx := context.Background()
+	var enabled atomic.Bool
+	var gated atomic.Int32
+	testSignal := signals.NewSync[int](signals.WithGate(func(ctx context.Context, v int) bool {
+		gated.Add(1)
+		return enabled.Load() || v < 0
+	}), signals.WithRateLimit(1000, 1)).(*signals.SyncSignal[int])
+
+	results := make([]int, 0)
+	testSignal.AddListenerWith(func(ctx context.Context, v int) {
+		results = append(results, v)
+	}, signals.WithTags("audit"))
+
+	require.ErrorIs(t, testSignal.Emit(ctx, 1), signals.ErrGated)
+	require.ErrorIs(t, testSignal.EmitTagged(ctx, 2, "audit"), signals.ErrGated)
+	ok, err := testSignal.TryEmit(ctx, 3)
+	require.False(t, ok)
+	require.ErrorIs(t, err, signals.ErrGated)
+	require.Empty(t, results)
+
+	// The rejected emissions took no token: the burst of one is still there.
+	require.NoError(t, testSignal.EmitBatch(ctx, []int{4, -1, 5}))
+	require.Equal(t, []int{-1}, results)
+
+	enabled.Store(true)
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, testSignal.Emit(ctx, 6))
+	require.Equal(t, []int{-1, 6}, results)
+	require.Equal(t, int32(7), gated.Load())
+
+	// A gate of another type is ignored.
+	other := signals.NewSync[string](signals.WithGate(func(ctx context.Context, v int) bool {
+		return false
+	}))
+	require.NoError(t, other.Emit(ctx, "a"))
+}
+
+func TestCompareAndEmit(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSticky[int]()
+	var received atomic.Int32
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		received.Add(1)
+	})
+
+	var wg sync.WaitGroup
+	var swapped atomic.Int32
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ok, err := testSignal.CompareAndEmit(ctx, 0, i)
+			assert.NoError(t, err)
+			if ok {
+				swapped.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), swapped.Load())
+	require.Equal(t, int32(1), received.Load())
+
+	var current int
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		current = v
+	})
+	ok, err := testSignal.CompareAndEmit(ctx, current, 100)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 100, current)
+
+	ok, err = testSignal.CompareAndEmit(ctx, 0, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, 100, current)
+
+	_, err = signals.New[int]().(signals.StickySignal[int]).CompareAndEmit(ctx, 0, 1)
+	require.ErrorIs(t, err, signals.ErrNotSticky)
+
+	lists := signals.NewSticky[[]string]()
+	ok, err = lists.CompareAndEmit(ctx, nil, []string{"a"})
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = lists.CompareAndEmit(ctx, []string{"a"}, []string{"b"})
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestAsyncResultSignalErrorOrder(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewAsyncResult[int]()
+
+	// The listeners fail in the reverse order of their registration.
+	for i := 1; i <= 3; i++ {
+		i := i
+		testSignal.AddListener(func(ctx context.Context, v int) error {
+			time.Sleep(time.Duration(4-i) * 5 * time.Millisecond)
+			return fmt.Errorf("listener %d failed", i)
+		})
+	}
+	testSignal.AddListener(func(ctx context.Context, v int) error {
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		err := testSignal.EmitAndWait(ctx, 1)
+		require.EqualError(t, err, "listener 1 failed\nlistener 2 failed\nlistener 3 failed")
+	}
+
+	_, ok := testSignal.Clone().Signal.(*signals.AsyncSignal[int])
+	require.True(t, ok)
+}
+
+func TestNullSignal(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewNull[int]()
+
+	invoked := false
+	require.Equal(t, 0, testSignal.AddListener(func(ctx context.Context, v int) {
+		invoked = true
+	}, signals.SignalType(1)))
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.NoError(t, testSignal.EmitBatch(ctx, []int{2, 3}))
+	require.False(t, invoked)
+	require.False(t, testSignal.HasListener(1))
+	require.True(t, testSignal.IsEmpty())
+
+	testSignal.Close()
+	require.NoError(t, testSignal.Emit(ctx, 5))
+	require.NoError(t, testSignal.Wait(ctx))
+
+	ctx, cancel := context.WithCancel(ctx)
+	values := testSignal.Subscribe(ctx, 1)
+	cancel()
+	_, ok := <-values
+	require.False(t, ok)
+	_, err := testSignal.WaitFor(ctx, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	rec := signals.NewRecorder[int](signals.WithGate(func(ctx context.Context, v int) bool {
+		return v >= 0
+	}))
+
+	require.NoError(t, rec.Emit(ctx, 1))
+	require.NoError(t, rec.EmitBatch(ctx, []int{2, -1, 3}))
+
+	results := make([]int, 0)
+	rec.AddListener(func(ctx context.Context, v int) {
+		results = append(results, v)
+	})
+	require.ErrorIs(t, rec.Emit(ctx, -2), signals.ErrGated)
+	require.NoError(t, rec.Emit(ctx, 4))
+	require.Equal(t, []int{1, 2, 3, 4}, rec.Values())
+	require.Equal(t, []int{4}, results)
+
+	rec.Reset()
+	require.Empty(t, rec.Values())
+	require.True(t, rec.IsEmpty())
+}
+
+type testBreakerObserver struct {
+	testObserver
+	states []signals.BreakerState
+}
+
+func (o *testBreakerObserver) OnBreakerStateChange(key signals.SignalType, state signals.BreakerState) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.states = append(o.states, state)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	obs := &testBreakerObserver{}
+	testSignal := signals.NewAsyncResult[int](signals.WithObserver(obs), signals.WithCircuitBreaker(2, 20*time.Millisecond))
+
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	testSignal.AddListener(func(ctx context.Context, v int) error {
+		calls.Add(1)
+		if !healthy.Load() {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	}, signals.SignalType(1))
+
+	require.Error(t, testSignal.Emit(ctx, 1))
+	require.Error(t, testSignal.Emit(ctx, 2))
+	// The breaker is open: the listener is skipped.
+	require.NoError(t, testSignal.Emit(ctx, 3))
+	require.Equal(t, int32(2), calls.Load())
+
+	// The trial after the cooldown fails and opens the breaker again.
+	time.Sleep(30 * time.Millisecond)
+	require.Error(t, testSignal.Emit(ctx, 4))
+	require.NoError(t, testSignal.Emit(ctx, 5))
+	require.Equal(t, int32(3), calls.Load())
+
+	healthy.Store(true)
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, testSignal.Emit(ctx, 6))
+	require.NoError(t, testSignal.Emit(ctx, 7))
+	require.Equal(t, int32(5), calls.Load())
+
+	require.Equal(t, []signals.BreakerState{
+		signals.BreakerOpen,
+		signals.BreakerHalfOpen, signals.BreakerOpen,
+		signals.BreakerHalfOpen, signals.BreakerClosed,
+	}, obs.states)
+	require.Equal(t, "half-open", signals.BreakerHalfOpen.String())
+}
+
+func TestContextDecorator(t *testing.T) {
+	type ctxKey string
+	ctx := context.WithValue(context.Background(), ctxKey("trace"), "emit")
+	testSignal := signals.New[int](signals.WithContextDecorator(func(ctx context.Context, key signals.SignalType) context.Context {
+		return context.WithValue(ctx, ctxKey("listener"), key)
+	}))
+
+	var mu sync.Mutex
+	seen := make(map[signals.SignalType]any)
+	for _, key := range []signals.SignalType{1, 2} {
+		key := key
+		testSignal.AddListenerWith(func(ctx context.Context, v int) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, "emit", ctx.Value(ctxKey("trace")))
+			seen[key] = ctx.Value(ctxKey("listener"))
+		}, key, signals.WithListenerTimeout(time.Second))
+	}
+
+	require.NoError(t, testSignal.EmitAndWait(ctx, 1))
+	require.Equal(t, map[signals.SignalType]any{1: signals.SignalType(1), 2: signals.SignalType(2)}, seen)
+}
+
+func TestParallel(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewParallel[int](2)
+
+	var running, peak, done atomic.Int32
+	for i := 0; i < 6; i++ {
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			done.Add(1)
+		})
+	}
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, int32(6), done.Load())
+	require.Equal(t, int32(2), peak.Load())
+
+	results := signals.NewParallelResult[int](0)
+	results.AddListener(func(ctx context.Context, v int) error {
+		time.Sleep(5 * time.Millisecond)
+		return errors.New("first")
+	})
+	results.AddListener(func(ctx context.Context, v int) error {
+		return errors.New("second")
+	})
+	require.EqualError(t, results.Emit(ctx, 1), "first\nsecond")
+}
+
+func TestCancelListener(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int]()
+
+	started := make(chan struct{})
+	var cancelled atomic.Int32
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		if v == 1 {
+			close(started)
+			<-ctx.Done()
+			cancelled.Add(1)
+		}
+	}, signals.SignalType(1))
+	var other atomic.Bool
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		other.Store(ctx.Err() == nil)
+	}, signals.SignalType(2))
+
+	require.Equal(t, 0, testSignal.CancelListener(1))
+	require.Equal(t, 0, testSignal.CancelListener(3))
+
+	done := make(chan error)
+	go func() { done <- testSignal.Emit(ctx, 1) }()
+	<-started
+	require.Equal(t, 1, testSignal.CancelListener(1))
+	require.NoError(t, <-done)
+	require.Equal(t, int32(1), cancelled.Load())
+	require.True(t, other.Load())
+
+	// The listener is still subscribed, and receives a fresh context.
+	require.True(t, testSignal.HasListener(1))
+	require.NoError(t, testSignal.Emit(ctx, 2))
+	require.True(t, other.Load())
+}
+
+func TestMustEmit(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int]()
+
+	var received []int
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		received = append(received, v)
+	})
+	require.NotPanics(t, func() { testSignal.MustEmit(ctx, 1) })
+	require.Equal(t, []int{1}, received)
+
+	testSignal.Close()
+	require.PanicsWithError(t, signals.ErrSignalClosed.Error(), func() { testSignal.MustEmit(ctx, 2) })
+
+	debounced := signals.NewDebounced[int](time.Millisecond)
+	debounced.Close()
+	require.Panics(t, func() { debounced.MustEmit(ctx, 1) })
+}
+
+func TestSubscribeWithDrops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	testSignal := signals.NewSync[int]()
+
+	values, dropped := testSignal.SubscribeWithDrops(ctx, 2)
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, testSignal.Emit(ctx, i))
+	}
+	_, ok := <-dropped
+	require.True(t, ok)
+
+	require.NoError(t, testSignal.Emit(ctx, 4))
+	require.NoError(t, testSignal.Emit(ctx, 5))
+	// The drops of 4 and 5 are coalesced into a single notification.
+	require.Len(t, dropped, 1)
+	<-dropped
+
+	cancel()
+	require.Eventually(t, func() bool { return testSignal.IsEmpty() }, time.Second, time.Millisecond)
+	var received []int
+	for v := range values {
+		received = append(received, v)
+	}
+	require.Equal(t, []int{1, 2}, received)
+	_, ok = <-dropped
+	require.False(t, ok)
+}
+
+// BenchmarkSignalSyncEmitLatency emits on a synchronous signal from many
+// goroutines at once and reports the distribution of the time each Emit took,
+// to check that no emitter is starved by the others.
+func BenchmarkSignalSyncEmitLatency(b *testing.B) {
+	testSignal := signals.NewSync[int]()
+	for i := 0; i < 8; i++ {
+		testSignal.AddListener(func(ctx context.Context, v int) {})
+	}
+
+	var mu sync.Mutex
+	var latencies []time.Duration
+	ctx := context.Background()
+	b.SetParallelism(16)
+	b.RunParallel(func(pb *testing.PB) {
+		var local []time.Duration
+		for pb.Next() {
+			start := time.Now()
+			_ = testSignal.Emit(ctx, 1)
+			local = append(local, time.Since(start))
+		}
+		mu.Lock()
+		latencies = append(latencies, local...)
+		mu.Unlock()
+	})
+
+	slices.Sort(latencies)
+	percentile := func(p float64) float64 {
+		return float64(latencies[int(p*float64(len(latencies)-1))].Nanoseconds())
+	}
+	b.ReportMetric(percentile(0.5), "p50-ns")
+	b.ReportMetric(percentile(0.99), "p99-ns")
+	b.ReportMetric(percentile(1), "max-ns")
+}
+
+// BenchmarkSignalAsyncConcurrentEmit emits on an asynchronous signal from an
+// increasing number of goroutines at once, to check that the emissions do not
+// serialize on the lock of the signal. The listeners do nothing, so the cost
+// measured is the one of the signal itself.
+func BenchmarkSignalAsyncConcurrentEmit(b *testing.B) {
+	testSignal := signals.New[int]()
+	for i := 0; i < 4; i++ {
+		testSignal.AddListener(func(ctx context.Context, v int) {})
+	}
+
+	ctx := context.Background()
+	for _, emitters := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("emitters=%d", emitters), func(b *testing.B) {
+			var wg sync.WaitGroup
+			for i := 0; i < emitters; i++ {
+				n := b.N / emitters
+				if i < b.N%emitters {
+					n++
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < n; j++ {
+						_ = testSignal.Emit(ctx, j)
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestDedup(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int](signals.WithDedup())
+
+	count := 0
+	listener := func(ctx context.Context, v int) {
+		count++
+	}
+	require.Equal(t, 1, testSignal.AddListener(listener))
+	require.Equal(t, -1, testSignal.AddListener(listener))
+	require.Equal(t, -1, testSignal.AddListenerOnce(listener, signals.SignalType(1)))
+	require.False(t, testSignal.HasListener(1))
+
+	other := 0
+	require.Equal(t, 2, testSignal.AddListener(func(ctx context.Context, v int) {
+		other++
+	}))
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, 1, count)
+	require.Equal(t, 1, other)
+
+	// Without the option, the same function can be added twice.
+	plain := signals.NewSync[int]()
+	plain.AddListener(listener)
+	plain.AddListener(listener)
+	require.NoError(t, plain.Emit(ctx, 1))
+	require.Equal(t, 3, count)
+
+	// The listeners connecting other signals are never duplicates.
+	first, second := signals.NewSync[int](), signals.NewSync[int]()
+	firstCount, secondCount := 0, 0
+	first.AddListener(func(ctx context.Context, v int) { firstCount++ })
+	second.AddListener(func(ctx context.Context, v int) { secondCount++ })
+	stopFirst := testSignal.Pipe(first)
+	stopSecond := testSignal.Pipe(second)
+	require.NotNil(t, stopFirst)
+	require.NotNil(t, stopSecond)
+	require.NoError(t, testSignal.Emit(ctx, 2))
+	require.Equal(t, 1, firstCount)
+	require.Equal(t, 1, secondCount)
+	stopFirst()
+	stopSecond()
+
+	full := signals.NewSync[int](signals.WithMaxListeners(1))
+	full.AddListener(listener)
+	require.Nil(t, full.Pipe(first))
+	mapped, closeMapped := signals.Map(full, func(v int) int { return v })
+	require.Nil(t, mapped)
+	require.Nil(t, closeMapped)
+	merged, closeMerged := signals.Merge[int](first, full)
+	require.Nil(t, merged)
+	require.Nil(t, closeMerged)
+	require.Equal(t, 1, first.Len())
+}
+
+func TestValidator(t *testing.T) {
+	ctx := context.Background()
+	errNegative := errors.New("negative value")
+	var gated []int
+	testSignal := signals.NewSync[int](signals.WithValidator(func(v int) error {
+		if v < 0 {
+			return errNegative
+		}
+		return nil
+	}), signals.WithGate(func(ctx context.Context, v int) bool {
+		gated = append(gated, v)
+		return v != 0
+	})).(*signals.SyncSignal[int])
+
+	results := make([]int, 0)
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		results = append(results, v)
+	})
+
+	require.ErrorIs(t, testSignal.Emit(ctx, -1), errNegative)
+	ok, err := testSignal.TryEmit(ctx, -2)
+	require.False(t, ok)
+	require.ErrorIs(t, err, errNegative)
+	require.ErrorIs(t, testSignal.EmitBatch(ctx, []int{1, -3, 2}), errNegative)
+	require.Empty(t, results)
+	require.Empty(t, gated)
+
+	require.ErrorIs(t, testSignal.Emit(ctx, 0), signals.ErrGated)
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, []int{1}, results)
+	require.Equal(t, []int{0, 1}, gated)
+}
+
+func TestHistorySince(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[int](signals.WithHistory(3))
+	require.Empty(t, testSignal.HistorySince(time.Time{}))
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.NoError(t, testSignal.Emit(ctx, 2))
+	time.Sleep(time.Millisecond)
+	mark := time.Now()
+	time.Sleep(time.Millisecond)
+	require.NoError(t, testSignal.Emit(ctx, 3))
+	require.NoError(t, testSignal.Emit(ctx, 4))
+
+	require.Equal(t, []int{3, 4}, testSignal.HistorySince(mark))
+	require.Equal(t, []int{2, 3, 4}, testSignal.HistorySince(time.Time{}))
+	require.Equal(t, []int{3, 4}, testSignal.Clone().HistorySince(mark))
+
+	// Once the ring wrapped past the mark, only the remaining values are
+	// returned.
+	require.NoError(t, testSignal.Emit(ctx, 5))
+	require.NoError(t, testSignal.Emit(ctx, 6))
+	require.Equal(t, []int{4, 5, 6}, testSignal.HistorySince(mark))
+	require.Empty(t, testSignal.HistorySince(time.Now()))
+}
+
+func TestErrorOnNoListeners(t *testing.T) {
+	type emitter interface {
+		signals.Signal[int]
+		EmitFunc(ctx context.Context, produce func() int) error
+		TryEmit(ctx context.Context, payload int) (bool, error)
+	}
+
+	ctx := context.Background()
+	for name, testSignal := range map[string]emitter{
+		"Sync":  signals.NewSync[int](signals.WithErrorOnNoListeners()).(*signals.SyncSignal[int]),
+		"Async": signals.New[int](signals.WithErrorOnNoListeners()).(*signals.AsyncSignal[int]),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, testSignal.Emit(ctx, 1), signals.ErrNoListeners)
+			require.ErrorIs(t, testSignal.EmitFunc(ctx, func() int { return 1 }), signals.ErrNoListeners)
+
+			var count atomic.Int32
+			testSignal.AddListenerFiltered(func(ctx context.Context, v int) {
+				count.Add(1)
+			}, func(v int) bool { return v > 0 })
+
+			require.ErrorIs(t, testSignal.Emit(ctx, -1), signals.ErrNoListeners)
+			require.ErrorIs(t, testSignal.EmitBatch(ctx, []int{-1, -2}), signals.ErrNoListeners)
+			ok, err := testSignal.TryEmit(ctx, -1)
+			require.True(t, ok)
+			require.ErrorIs(t, err, signals.ErrNoListeners)
+
+			require.NoError(t, testSignal.Emit(ctx, 1))
+			require.Equal(t, int32(1), count.Load())
+		})
+	}
+
+	require.NoError(t, signals.NewSync[int]().Emit(ctx, 1))
+
+	// The wrappers report it like the signal they wrap.
+	produce := func() int { return 1 }
+	debounced := signals.Debounce[int](signals.NewSync[int](signals.WithErrorOnNoListeners()), time.Millisecond)
+	require.ErrorIs(t, debounced.EmitFunc(ctx, produce), signals.ErrNoListeners)
+	throttled := signals.Throttle[int](signals.NewSync[int](signals.WithErrorOnNoListeners()), time.Millisecond)
+	require.ErrorIs(t, throttled.EmitFunc(ctx, produce), signals.ErrNoListeners)
+	require.NoError(t, signals.Debounce[int](signals.NewSync[int](), time.Millisecond).EmitFunc(ctx, produce))
+}
+
+func TestMeta(t *testing.T) {
+	type requestMeta struct{ ID string }
+	type userMeta struct{ ID int }
+
+	ctx := signals.WithMeta(context.Background(), requestMeta{ID: "req-1"})
+	ctx = signals.WithMeta(ctx, userMeta{ID: 42})
+
+	testSignal := signals.New[int]()
+	var request requestMeta
+	var user userMeta
+	var missing bool
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		request, _ = signals.MetaFrom[requestMeta](ctx)
+		user, _ = signals.MetaFrom[userMeta](ctx)
+		_, missing = signals.MetaFrom[string](ctx)
+	})
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, requestMeta{ID: "req-1"}, request)
+	require.Equal(t, userMeta{ID: 42}, user)
+	require.False(t, missing)
+}
+
+func TestResetAndCancel(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int]()
+
+	started := make(chan struct{})
+	var cancelled atomic.Bool
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		close(started)
+		<-ctx.Done()
+		cancelled.Store(true)
+	})
+
+	done := make(chan error)
+	go func() { done <- testSignal.Emit(ctx, 1) }()
+	<-started
+
+	require.NoError(t, testSignal.ResetAndCancel(ctx))
+	require.True(t, cancelled.Load())
+	require.True(t, testSignal.IsEmpty())
+	require.NoError(t, <-done)
+
+	// A listener ignoring its context is waited for until the context is done.
+	started, release := make(chan struct{}), make(chan struct{})
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		close(started)
+		<-release
+	})
+	go func() { done <- testSignal.Emit(ctx, 2) }()
+	<-started
+	timeout, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, testSignal.ResetAndCancel(timeout), context.DeadlineExceeded)
+	close(release)
+	require.NoError(t, <-done)
+}
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewAsyncResult[int](signals.WithPausePolicy(signals.PauseDrop))
+
+	release := make(chan struct{})
+	testSignal.AddListener(func(ctx context.Context, v int) error {
+		switch v {
+		case 1:
+			return errors.New("failed")
+		case 2:
+			panic("boom")
+		case 3:
+			<-release
+		}
+		return nil
+	})
+	testSignal.AddListener(func(ctx context.Context, v int) error { return nil })
+
+	require.Error(t, testSignal.Emit(ctx, 1))
+	require.Error(t, testSignal.Emit(ctx, 2))
+	require.NoError(t, testSignal.EmitBatch(ctx, []int{4, 5}))
+	testSignal.Pause()
+	require.ErrorIs(t, testSignal.Emit(ctx, 6), signals.ErrPaused)
+	testSignal.Resume()
+
+	done := make(chan error)
+	go func() { done <- testSignal.Emit(ctx, 3) }()
+	require.Eventually(t, func() bool {
+		return testSignal.Stats().InFlight == 1
+	}, time.Second, time.Millisecond)
+
+	require.Equal(t, signals.Stats{
+		Listeners: 2,
+		Emits:     4,
+		InFlight:  1,
+		Dropped:   1,
+		Panicked:  1,
+		Errored:   1,
+	}, testSignal.Stats())
+
+	testSignal.Reset()
+	require.Equal(t, signals.Stats{InFlight: 1}, testSignal.Stats())
+	close(release)
+	require.NoError(t, <-done)
+	require.Equal(t, signals.Stats{}, testSignal.Stats())
+}
+
+func TestSignalAsyncPriority(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int](signals.WithMaxConcurrency(1))
+
+	var mu sync.Mutex
+	var starts []time.Time
+	var order []int
+	for _, priority := range []int{0, 10, -5, 5} {
+		priority := priority
+		testSignal.AddListenerWith(func(ctx context.Context, v int) {
+			mu.Lock()
+			defer mu.Unlock()
+			starts = append(starts, time.Now())
+			order = append(order, priority)
+		}, signals.WithPriority(priority))
+	}
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.Equal(t, []int{10, 5, 0, -5}, order)
+	require.True(t, slices.IsSortedFunc(starts, func(a, b time.Time) int { return a.Compare(b) }))
+}
+
+func TestSelectFirst(t *testing.T) {
+	ctx := context.Background()
+	first, second := signals.NewSync[int](), signals.New[int]()
+
+	go func() {
+		for second.IsEmpty() {
+			runtime.Gosched()
+		}
+		_ = second.Emit(ctx, 42)
+	}()
+	i, v, err := signals.SelectFirst(ctx, first, second)
+	require.NoError(t, err)
+	require.Equal(t, 1, i)
+	require.Equal(t, 42, v)
+	require.True(t, first.IsEmpty())
+	require.True(t, second.IsEmpty())
+
+	timeout, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+	i, _, err = signals.SelectFirst(timeout, first, second)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, -1, i)
+	require.True(t, first.IsEmpty())
+}
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RoundRobin", func(t *testing.T) {
+		queue := signals.NewQueue[int]()
+		require.ErrorIs(t, queue.Emit(ctx, 0), signals.ErrNoListeners)
+
+		received := make(map[int][]int)
+		for worker := 0; worker < 3; worker++ {
+			worker := worker
+			queue.AddListener(func(ctx context.Context, v int) {
+				received[worker] = append(received[worker], v)
+			})
+		}
+		for i := 1; i <= 6; i++ {
+			n, err := queue.EmitN(ctx, i)
+			require.NoError(t, err)
+			require.Equal(t, 1, n)
+		}
+		require.Equal(t, map[int][]int{0: {1, 4}, 1: {2, 5}, 2: {3, 6}}, received)
+	})
+
+	t.Run("LeastBusy", func(t *testing.T) {
+		queue := signals.NewQueue[int](signals.WithSelection(signals.LeastBusy))
+		release := make(chan struct{})
+		started := make(chan struct{})
+		var busy, idle atomic.Int32
+		queue.AddListener(func(ctx context.Context, v int) {
+			busy.Add(1)
+			close(started)
+			<-release
+		})
+		queue.AddListener(func(ctx context.Context, v int) {
+			idle.Add(1)
+		})
+
+		done := make(chan error)
+		go func() { done <- queue.Emit(ctx, 1) }()
+		<-started
+		for i := 0; i < 3; i++ {
+			require.NoError(t, queue.Emit(ctx, 2))
+		}
+		close(release)
+		require.NoError(t, <-done)
+		require.Equal(t, int32(1), busy.Load())
+		require.Equal(t, int32(3), idle.Load())
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		queue := signals.NewQueue[int](signals.WithSelection(signals.Random))
+		var count atomic.Int32
+		for i := 0; i < 3; i++ {
+			queue.AddListener(func(ctx context.Context, v int) {
+				count.Add(1)
+			})
+		}
+		for i := 0; i < 10; i++ {
+			require.NoError(t, queue.Emit(ctx, i))
+		}
+		require.Equal(t, int32(10), count.Load())
+	})
+
+	t.Run("Backlog", func(t *testing.T) {
+		var queue signals.Signal[int] = signals.NewQueue[int](signals.WithBacklog(2))
+		require.NoError(t, queue.Emit(ctx, 1))
+		require.NoError(t, queue.Emit(ctx, 2))
+		require.ErrorIs(t, queue.Emit(ctx, 3), signals.ErrDropped)
+
+		var received []int
+		queue.AddListener(func(ctx context.Context, v int) {
+			received = append(received, v)
+		})
+		require.Equal(t, []int{1, 2}, received)
+		require.NoError(t, queue.Emit(ctx, 4))
+		require.Equal(t, []int{1, 2, 4}, received)
+	})
+
+	t.Run("BacklogErrors", func(t *testing.T) {
+		queue := signals.NewQueue[int](signals.WithBacklog(2))
+		require.NoError(t, queue.Emit(ctx, 1))
+		queue.AddListener(func(ctx context.Context, v int) {
+			panic("boom")
+		})
+		require.Equal(t, uint64(1), queue.Stats().Panicked)
+
+		queue.Reset()
+		require.NoError(t, queue.Emit(ctx, 2))
+		require.NoError(t, queue.Emit(ctx, 3))
+		queue.Close()
+		queue.AddListener(func(ctx context.Context, v int) {})
+		require.Equal(t, uint64(2), queue.Stats().Dropped)
+	})
+}
+
+func TestCoalesce(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[string](signals.WithCoalesce[string](30 * time.Millisecond))
+
+	var mu sync.Mutex
+	var received []string
+	testSignal.AddListener(func(ctx context.Context, key string) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, key)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, testSignal.Emit(ctx, "users"))
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, testSignal.EmitBatch(ctx, []string{"users", "orders", "orders"}))
+	require.Equal(t, []string{"users", "orders"}, received)
+
+	time.Sleep(40 * time.Millisecond)
+	require.NoError(t, testSignal.Emit(ctx, "users"))
+	require.Equal(t, []string{"users", "orders", "users"}, received)
+
+	testSignal.Reset()
+	testSignal.AddListener(func(ctx context.Context, key string) {
+		received = append(received, key)
+	})
+	require.NoError(t, testSignal.Emit(ctx, "users"))
+	require.Equal(t, []string{"users", "orders", "users", "users"}, received)
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	testSignal := signals.New[int](signals.WithDefaultTimeout(time.Second))
+
+	var deadline atomic.Value
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		d, ok := ctx.Deadline()
+		require.True(t, ok)
+		deadline.Store(d)
+	})
+
+	before := time.Now()
+	require.NoError(t, testSignal.Emit(context.Background(), 1))
+	d := deadline.Load().(time.Time)
+	require.False(t, d.Before(before.Add(time.Second)))
+	require.False(t, d.After(time.Now().Add(time.Second)))
+
+	// An earlier deadline of the caller is kept.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.NoError(t, testSignal.Emit(ctx, 2))
+	want, _ := ctx.Deadline()
+	require.Equal(t, want, deadline.Load())
+
+	// A later deadline of the caller is shortened.
+	require.NoError(t, testSignal.EmitWithTimeout(context.Background(), 3, time.Hour))
+	require.True(t, deadline.Load().(time.Time).Before(time.Now().Add(time.Second)))
+
+	blocked := signals.NewSync[int](signals.WithDefaultTimeout(10*time.Millisecond), signals.WithBuffer(1, signals.Block))
+	release := make(chan struct{})
+	blocked.AddListener(func(ctx context.Context, v int) {
+		<-release
+	})
+	require.NoError(t, blocked.Emit(context.Background(), 1))
+	require.NoError(t, blocked.Emit(context.Background(), 2))
+	require.ErrorIs(t, blocked.Emit(context.Background(), 3), context.DeadlineExceeded)
+	close(release)
+	require.NoError(t, blocked.Wait(context.Background()))
+}
+
+func TestPanicErrorStack(t *testing.T) {
+	var hooked atomic.Pointer[signals.PanicError]
+	testSignal := signals.New[int](signals.WithOnPanicError(func(err *signals.PanicError) {
+		hooked.Store(err)
+	}))
+	testSignal.AddListener(func(ctx context.Context, v int) {})
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		panic("boom")
+	})
+
+	err := testSignal.EmitAndWait(context.Background(), 1)
+	var panicked *signals.PanicError
+	require.ErrorAs(t, err, &panicked)
+	require.Equal(t, "boom", panicked.Value)
+	require.Contains(t, string(panicked.Stack), "TestPanicErrorStack")
+	require.Same(t, panicked, hooked.Load())
+	require.Equal(t, uint64(1), testSignal.Stats().Panicked)
+}
+
+func TestWithIsolation(t *testing.T) {
+	ctx := context.Background()
+	setup := func(opts ...signals.SignalOption) (signals.Signal[int], *[]string) {
+		testSignal := signals.NewSync[int](opts...)
+		results := make([]string, 0)
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			results = append(results, "first")
+		})
+		testSignal.AddListenerFiltered(func(ctx context.Context, v int) {
+			results = append(results, "filtered")
+		}, func(v int) bool {
+			panic("bad filter")
+		}, signals.SignalType(2))
+		testSignal.AddListener(func(ctx context.Context, v int) {
+			results = append(results, "last")
+		})
+		return testSignal, &results
+	}
+
+	isolated, results := setup(signals.WithIsolation())
+	err := isolated.Emit(ctx, 1)
+	var panicked *signals.PanicError
+	require.ErrorAs(t, err, &panicked)
+	require.Equal(t, signals.SignalType(2), panicked.Key)
+	require.Equal(t, "bad filter", panicked.Value)
+	require.Equal(t, []string{"first", "last"}, *results)
+
+	// Without the option, the panic of the filter stops the emission.
+	failFast, results := setup()
+	require.PanicsWithValue(t, "bad filter", func() {
+		_ = failFast.Emit(ctx, 1)
+	})
+	require.Equal(t, []string{"first"}, *results)
+}
+
+func TestRouter(t *testing.T) {
+	ctx := context.Background()
+	router := signals.NewRouter[string, string]()
+	require.ErrorIs(t, router.Emit(ctx, "acme/1"), signals.ErrNoRoute)
+
+	router.Route(func(v string) string {
+		tenant, _, _ := strings.Cut(v, "/")
+		return tenant
+	})
+
+	var mu sync.Mutex
+	received := map[string][]string{}
+	record := func(name string) signals.SignalListener[string] {
+		return func(ctx context.Context, v string) {
+			mu.Lock()
+			defer mu.Unlock()
+			received[name] = append(received[name], v)
+		}
+	}
+	router.On("acme", record("acme"))
+	router.On("globex", record("globex"))
+	router.OnDefault(record("default"))
+
+	for _, v := range []string{"acme/1", "globex/1", "initech/1", "acme/2"} {
+		require.NoError(t, router.Emit(ctx, v))
+	}
+	require.Equal(t, map[string][]string{
+		"acme":    {"acme/1", "acme/2"},
+		"globex":  {"globex/1"},
+		"default": {"initech/1"},
+	}, received)
+
+	router.Child("globex").Reset()
+	require.NoError(t, router.Emit(ctx, "globex/2"))
+	require.Equal(t, []string{"initech/1", "globex/2"}, received["default"])
+
+	router.Close()
+	require.ErrorIs(t, router.Emit(ctx, "acme/3"), signals.ErrSignalClosed)
+	router.On("hooli", record("hooli"))
+	require.ErrorIs(t, router.Emit(ctx, "hooli/1"), signals.ErrSignalClosed)
+}
+
+func TestKeyOf(t *testing.T) {
+	type tenant string
+
+	require.Equal(t, signals.KeyOf("user.created"), signals.KeyOf("user.created"))
+	require.NotEqual(t, signals.KeyOf("user.created"), signals.KeyOf("user.deleted"))
+	require.NotEqual(t, signals.KeyOf("acme"), signals.KeyOf(tenant("acme")))
+	require.Negative(t, int(signals.KeyOf(42)))
+
+	name, ok := signals.KeyValue(signals.KeyOf("user.created"))
+	require.True(t, ok)
+	require.Equal(t, "user.created", name)
+	_, ok = signals.KeyValue(signals.SignalType(1))
+	require.False(t, ok)
+
+	testSignal := signals.NewSync[int]()
+	var count int
+	listener := func(ctx context.Context, v int) { count++ }
+	require.Equal(t, 1, signals.AddListenerKeyed(testSignal, listener, "user.created"))
+	require.Equal(t, -1, signals.AddListenerKeyed(testSignal, listener, "user.created"))
+	require.Equal(t, 2, testSignal.AddListener(listener, signals.SignalType(1)))
+	require.True(t, testSignal.HasListener(signals.KeyOf("user.created")))
+	require.Equal(t, []signals.SignalType{signals.KeyOf("user.created"), 1}, testSignal.Keys())
+	require.Equal(t, 3, testSignal.AddListener(listener))
+	require.Len(t, testSignal.Keys(), 2)
+
+	require.NoError(t, testSignal.Emit(context.Background(), 1))
+	require.Equal(t, 3, count)
+	require.Equal(t, 2, testSignal.RemoveListener(signals.KeyOf("user.created")))
+	require.False(t, testSignal.HasListener(signals.KeyOf("user.created")))
+
+	// An untyped constant and a SignalType are the same key.
+	testSignal = signals.NewSync[int]()
+	require.Equal(t, 1, testSignal.AddListener(listener, 1))
+	require.Equal(t, -1, testSignal.AddListener(listener, signals.SignalType(1)))
+	require.Equal(t, -1, testSignal.AddListenerWith(listener, signals.SignalType(1)))
+	require.Equal(t, []signals.SignalType{1}, testSignal.Keys())
+}
+
+func TestEmitWithAck(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewAsyncResult[int]()
+	release := make(chan struct{})
+	errFailed := errors.New("failed")
+	testSignal.AddListener(func(ctx context.Context, v int) error {
+		<-release
+		return nil
+	}, signals.SignalType(1))
+	testSignal.AddListener(func(ctx context.Context, v int) error {
+		return errFailed
+	}, signals.SignalType(2))
+
+	acks, err := testSignal.EmitWithAck(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, signals.Ack{Key: 2, Err: errFailed}, <-acks)
+
+	close(release)
+	require.Equal(t, signals.Ack{Key: 1}, <-acks)
+	_, ok := <-acks
+	require.False(t, ok)
+
+	testSignal.Close()
+	acks, err = testSignal.EmitWithAck(ctx, 2)
+	require.ErrorIs(t, err, signals.ErrSignalClosed)
+	_, ok = <-acks
+	require.False(t, ok)
+}
+
+func TestSeqFrom(t *testing.T) {
+	ctx := context.Background()
+	_, ok := signals.SeqFrom(ctx)
+	require.False(t, ok)
+
+	testSignal := signals.NewSync[int]().(*signals.SyncSignal[int])
+	var seqs []uint64
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		seq, ok := signals.SeqFrom(ctx)
+		require.True(t, ok)
+		seqs = append(seqs, seq)
+	}, signals.SignalType(1))
+
+	require.NoError(t, testSignal.Emit(ctx, 1))
+	require.NoError(t, testSignal.EmitBatch(ctx, []int{2, 3}))
+	ok, err := testSignal.TryEmit(ctx, 4)
+	require.True(t, ok)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 2, 3}, seqs)
+
+	testSignal.Reset()
+	seqs = nil
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		seq, _ := signals.SeqFrom(ctx)
+		seqs = append(seqs, seq)
+	})
+	require.NoError(t, testSignal.Emit(ctx, 5))
+	require.Equal(t, []uint64{1}, seqs)
+}
+
+func TestDrain(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.New[int](signals.WithBuffer(8, signals.Block))
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var received []int
+	testSignal.AddListener(func(ctx context.Context, v int) {
+		if v == 1 {
+			close(started)
+			<-release
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, v)
+	})
+
+	for i := 1; i <= 4; i++ {
+		require.NoError(t, testSignal.Emit(ctx, i))
+	}
+	<-started
+	testSignal.Close()
+	require.Equal(t, []int{2, 3, 4}, testSignal.Drain())
+	require.Equal(t, 0, testSignal.QueueDepth())
+	require.Empty(t, testSignal.Drain())
+
+	close(release)
+	require.NoError(t, testSignal.Wait(ctx))
+	require.Equal(t, []int{1}, received)
+
+	paused := signals.NewSync[int](signals.WithPausePolicy(signals.PauseReplay))
+	paused.AddListener(func(ctx context.Context, v int) {
+		t.Fatal("drained values must not be delivered")
+	})
+	paused.Pause()
+	require.NoError(t, paused.Emit(ctx, 1))
+	require.NoError(t, paused.Emit(ctx, 2))
+	require.Equal(t, []int{1, 2}, paused.Drain())
+	paused.Resume()
+}
+
+func TestEmitMany(t *testing.T) {
+	ctx := context.Background()
+	for _, testSignal := range []signals.Signal[int]{signals.NewSync[int](), signals.New[int]()} {
+		var mu sync.Mutex
+		received := map[int][]int{}
+		for key := 1; key <= 3; key++ {
+			key := key
+			testSignal.AddListener(func(ctx context.Context, v int) {
+				mu.Lock()
+				defer mu.Unlock()
+				received[key] = append(received[key], v)
+			})
+		}
+		var batches [][]int
+		testSignal.AddBatchListener(func(ctx context.Context, values []int) {
+			mu.Lock()
+			defer mu.Unlock()
+			batches = append(batches, values)
+		})
+
+		require.NoError(t, testSignal.EmitMany(ctx, 1, 2, 3))
+		require.NoError(t, testSignal.EmitMany(ctx))
+		require.Equal(t, map[int][]int{1: {1, 2, 3}, 2: {1, 2, 3}, 3: {1, 2, 3}}, received)
+		require.Equal(t, [][]int{{1, 2, 3}}, batches)
+	}
+}
+
+type testEvictionObserver struct {
+	testObserver
+	evicted []signals.SignalType
+}
+
+func (o *testEvictionObserver) OnListenerEvicted(key signals.SignalType) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.evicted = append(o.evicted, key)
+}
+
+func TestSlowListenerEviction(t *testing.T) {
+	ctx := context.Background()
+	obs := &testEvictionObserver{}
+	testSignal := signals.New[time.Duration](
+		signals.WithSlowListenerEviction(20*time.Millisecond, 2),
+		signals.WithMaxConcurrency(1),
+		signals.WithObserver(obs),
+	)
+	testSignal.AddListener(func(ctx context.Context, d time.Duration) {
+		time.Sleep(d)
+	}, signals.SignalType(1))
+	testSignal.AddListener(func(ctx context.Context, d time.Duration) {
+		time.Sleep(d / 2)
+	}, signals.SignalType(2))
+
+	// A slow invocation followed by a fast one resets the count.
+	require.NoError(t, testSignal.Emit(ctx, 30*time.Millisecond))
+	require.NoError(t, testSignal.Emit(ctx, 0))
+	require.NoError(t, testSignal.Emit(ctx, 30*time.Millisecond))
+	require.True(t, testSignal.HasListener(1))
+
+	// The time spent waiting for the concurrency slot is not counted against
+	// the second listener.
+	require.NoError(t, testSignal.Emit(ctx, 30*time.Millisecond))
+	require.False(t, testSignal.HasListener(1))
+	require.True(t, testSignal.HasListener(2))
+	require.Equal(t, []signals.SignalType{1}, obs.evicted)
+}
+
+func TestEmitWithPriority(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewSync[string](signals.WithBuffer(8, signals.Block))
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var received []string
+	testSignal.AddListener(func(ctx context.Context, v string) {
+		if v == "first" {
+			close(started)
+			<-release
+		}
+		received = append(received, v)
+	})
+
+	require.NoError(t, testSignal.Emit(ctx, "first"))
+	<-started
+	require.NoError(t, testSignal.Emit(ctx, "bulk-1"))
+	require.NoError(t, testSignal.EmitWithPriority(ctx, "low", -1))
+	require.NoError(t, testSignal.Emit(ctx, "bulk-2"))
+	require.NoError(t, testSignal.EmitWithPriority(ctx, "urgent-1", 10))
+	require.NoError(t, testSignal.EmitWithPriority(ctx, "urgent-2", 10))
+	close(release)
+	require.NoError(t, testSignal.Wait(ctx))
+	require.Equal(t, []string{"first", "urgent-1", "urgent-2", "bulk-1", "bulk-2", "low"}, received)
+
+	dropping := signals.NewSync[string](signals.WithBuffer(2, signals.DropOldest))
+	started = make(chan struct{})
+	release = make(chan struct{})
+	dropping.AddListener(func(ctx context.Context, v string) {
+		if v == "first" {
+			close(started)
+			<-release
+		}
+	})
+	require.NoError(t, dropping.Emit(ctx, "first"))
+	<-started
+	require.NoError(t, dropping.EmitWithPriority(ctx, "urgent", 10))
+	require.NoError(t, dropping.Emit(ctx, "bulk-1"))
+	require.ErrorIs(t, dropping.Emit(ctx, "bulk-2"), signals.ErrDropped)
+	require.Equal(t, []string{"urgent", "bulk-2"}, dropping.Drain())
+	close(release)
+	require.NoError(t, dropping.Wait(ctx))
+}
+
+func TestKey(t *testing.T) {
+	const (
+		counter  signals.Key[int]    = 1
+		logger   signals.Key[string] = 1
+		negative signals.Key[int]    = -1
+	)
+
+	ints := signals.NewSync[int]()
+	strs := signals.NewSync[string]()
+	require.Equal(t, 1, counter.AddTo(ints, func(ctx context.Context, v int) {}))
+	require.Equal(t, 1, logger.AddTo(strs, func(ctx context.Context, v string) {}))
+	require.Equal(t, 2, negative.AddTo(ints, func(ctx context.Context, v int) {}))
+	require.Equal(t, -1, negative.AddTo(ints, func(ctx context.Context, v int) {}))
+	require.True(t, ints.HasListener(counter.Type()))
+	require.True(t, logger.AddedTo(strs))
+	require.Equal(t, 0, logger.RemoveFrom(strs))
+	require.False(t, logger.AddedTo(strs))
+}
+
+func TestSubscribeWithHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	testSignal := signals.New[int](signals.WithHistory(3))
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, testSignal.Emit(ctx, i))
+	}
+
+	// Concurrent emissions are received exactly once, after the history.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 6; i <= 50; i++ {
+			assert.NoError(t, testSignal.Emit(ctx, i))
+		}
+	}()
+	values := testSignal.SubscribeWithHistory(ctx, 64)
+	<-done
+
+	var received []int
+	for len(received) == 0 || received[len(received)-1] != 50 {
+		received = append(received, <-values)
+	}
+	require.GreaterOrEqual(t, received[0], 3)
+	for i := 1; i < len(received); i++ {
+		require.Equal(t, received[i-1]+1, received[i])
+	}
+
+	sticky := signals.NewSync[int](signals.WithReplay())
+	require.NoError(t, sticky.Emit(ctx, 7))
+	replayed := sticky.SubscribeWithHistory(ctx, 0)
+	require.Equal(t, 7, <-replayed)
+	require.NoError(t, sticky.Emit(ctx, 8))
+	require.Equal(t, 8, <-replayed)
+	require.Equal(t, 0, len(replayed))
+}
+
+func TestAddListenerDelta(t *testing.T) {
+	type pair struct {
+		prev, curr int
+		ok         bool
+	}
+
+	ctx := context.Background()
+	signal := signals.NewSync[int]()
+	var pairs []pair
+	signal.AddListenerDelta(func(ctx context.Context, prev, curr int, ok bool) {
+		pairs = append(pairs, pair{prev, curr, ok})
+	})
+
+	require.NoError(t, signal.Emit(ctx, 1))
+	require.NoError(t, signal.Emit(ctx, 2))
+	require.NoError(t, signal.EmitBatch(ctx, []int{3, 4}))
+	require.NoError(t, signal.Emit(ctx, 5))
+	require.Equal(t, []pair{{0, 1, false}, {1, 2, true}, {2, 3, true}, {3, 4, true}, {4, 5, true}}, pairs)
+
+	signal.Reset()
+	pairs = nil
+	signal.AddListenerDelta(func(ctx context.Context, prev, curr int, ok bool) {
+		pairs = append(pairs, pair{prev, curr, ok})
+	})
+	require.NoError(t, signal.Emit(ctx, 6))
+	require.Equal(t, []pair{{0, 6, false}}, pairs)
+
+	async := signals.New[int]()
+	var mu sync.Mutex
+	prevs := make(map[int]int)
+	firsts := 0
+	async.AddListenerDelta(func(ctx context.Context, prev, curr int, ok bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !ok {
+			firsts++
+			return
+		}
+		prevs[prev]++
+	})
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, async.Emit(ctx, i))
+		}(i)
+	}
+	wg.Wait()
+
+	// Each value but the last one is the previous value of exactly one
+	// emission, and only the first emission has no previous value.
+	require.Equal(t, 1, firsts)
+	require.Len(t, prevs, 49)
+	for prev, n := range prevs {
+		require.Equal(t, 1, n, "value %d", prev)
+	}
+}
+
+func TestAddAbortableListener(t *testing.T) {
+	ctx := context.Background()
+	testSignal := signals.NewStoppable[int]()
+	results := make([]string, 0)
+	invalid := errors.New("negative value")
+
+	testSignal.AddListener(func(ctx context.Context, v int) bool {
+		results = append(results, "first")
+		return true
+	}, signals.WithPriority(20))
+	testSignal.AddAbortableListener(func(ctx context.Context, v int) (bool, error) {
+		results = append(results, "validate")
+		if v < 0 {
+			return false, invalid
+		}
+		return v == 0, nil
+	}, signals.SignalType(7), signals.WithPriority(10))
+	testSignal.AddListener(func(ctx context.Context, v int) bool {
+		results = append(results, "last")
+		return true
+	})
+
+	ran, err := testSignal.Emit(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, ran)
+	require.Equal(t, []string{"first", "validate", "last"}, results)
+
+	results = results[:0]
+	ran, err = testSignal.Emit(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, ran)
+	require.Equal(t, []string{"first", "validate"}, results)
+
+	results = results[:0]
+	ran, err = testSignal.Emit(ctx, -1)
+	require.ErrorIs(t, err, signals.ErrAborted)
+	require.ErrorIs(t, err, invalid)
+	var abort *signals.AbortError
+	require.ErrorAs(t, err, &abort)
+	require.Equal(t, signals.SignalType(7), abort.Key)
+	require.Equal(t, 2, ran)
+	require.Equal(t, []string{"first", "validate"}, results)
+
+	results = results[:0]
+	require.ErrorIs(t, testSignal.EmitBatch(ctx, []int{1, -1, -2, 3}), signals.ErrAborted)
+	require.Equal(t, []string{"first", "first", "first", "first", "validate", "validate"}, results)
 }
@@ -0,0 +1,94 @@
+package signals
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// Stats is a snapshot of the counters of a signal, returned by Stats.
+type Stats struct {
+	// Listeners is the number of listeners subscribed to the signal, like
+	// Len.
+	Listeners int
+
+	// Emits is the number of emissions dispatched to the listeners. A batch
+	// emitted with EmitBatch counts as one emission. The emissions rejected
+	// before the listeners were looked up, for instance by the gate or the
+	// rate limit, and the values still queued in a buffer, are not counted.
+	Emits uint64
+
+	// InFlight is the number of listener invocations running.
+	InFlight int64
+
+	// Dropped is the number of values discarded because the buffer set with
+	// WithBuffer or the backlog set with WithBacklog was full, because the
+	// signal was paused with the PauseDrop policy, or because the signal was
+	// closed before a listener took the values of its backlog.
+	Dropped uint64
+
+	// Panicked is the number of listener invocations that panicked.
+	Panicked uint64
+
+	// Errored is the number of listener invocations that returned an error.
+	// The invocations that panicked are not included.
+	Errored uint64
+}
+
+// counters holds the counters reported by Stats. They are updated atomically,
+// without the lock of the signal.
+type counters struct {
+	emits    atomic.Uint64
+	inFlight atomic.Int64
+	dropped  atomic.Uint64
+	panicked atomic.Uint64
+	errored  atomic.Uint64
+}
+
+// finished counts an invocation that returned err.
+func (c *counters) finished(err error) {
+	if err == nil {
+		return
+	}
+
+	var panicked *PanicError
+	if errors.As(err, &panicked) {
+		c.panicked.Add(1)
+	} else {
+		c.errored.Add(1)
+	}
+}
+
+// reset sets the cumulative counters back to zero. InFlight, which counts the
+// invocations running, is left alone.
+func (c *counters) reset() {
+	c.emits.Store(0)
+	c.dropped.Store(0)
+	c.panicked.Store(0)
+	c.errored.Store(0)
+}
+
+// Stats returns a snapshot of the counters of the signal, for instance for a
+// dashboard or a debug endpoint. The counters are maintained atomically and
+// read one after the other, so a snapshot taken during emissions may mix
+// values from slightly different instants.
+//
+// Reset and ResetAndCancel set the cumulative counters, Emits, Dropped,
+// Panicked and Errored, back to zero. InFlight is not reset: it keeps
+// counting the invocations still running, whose outcome is counted when they
+// return, after the reset. A clone starts with all its counters at zero.
+//
+// Example:
+//
+//	http.HandleFunc("/debug/events", func(w http.ResponseWriter, r *http.Request) {
+//		json.NewEncoder(w).Encode(signal.Stats())
+//	})
+func (s *BaseSignal[T]) Stats() Stats {
+	return Stats{
+		Listeners: s.Len(),
+		Emits:     s.stats.emits.Load(),
+		InFlight:  s.stats.inFlight.Load(),
+		Dropped:   s.stats.dropped.Load(),
+		Panicked:  s.stats.panicked.Load(),
+		Errored:   s.stats.errored.Load(),
+	}
+}
@@ -0,0 +1,249 @@
+package signals
+
+import (
+	"context"
+	"sync"
+)
+
+// Subscribe returns a channel that receives every value emitted by the signal
+// until the context is cancelled. When the context is cancelled, the
+// underlying listener is removed from the signal and the channel is closed.
+//
+// The channel has a buffer of the given size; a size <= 0 means an unbuffered
+// channel. Values are never waited on: if the buffer is full, or if the
+// channel is unbuffered and the consumer is not ready to receive, the emitted
+// value is dropped for this subscriber. This way a slow consumer never blocks
+// the emission or the other listeners.
+//
+// If the signal refuses the listener, for instance because it has the maximum
+// number of listeners set with WithMaxListeners, the returned channel is
+// closed right away.
+//
+// Example:
+//
+//	signal := signals.New[int]()
+//	values := signal.Subscribe(ctx, 16)
+//	for {
+//		select {
+//		case v, ok := <-values:
+//			if !ok {
+//				return // The context was cancelled
+//			}
+//			fmt.Println("Received:", v)
+//		case <-other:
+//			// ...
+//		}
+//	}
+func (s *BaseSignal[T]) Subscribe(ctx context.Context, bufferSize int) <-chan T {
+	return s.subscribe(ctx, bufferSize, nil, false)
+}
+
+// SubscribeWithHistory returns a channel that first receives the values of the
+// history of a signal created with WithHistory, oldest first, and then every
+// value emitted afterwards like Subscribe, so a reader can catch up and then
+// follow the stream. The history is taken atomically with the subscription:
+// a value emitted concurrently is received exactly once, either from the
+// history or as a live value, and the live values always come after the
+// history. For a signal created with WithReplay and without history, the
+// replayed value plays the role of the history.
+//
+// The channel gets room for the whole history on top of the given buffer
+// size, so the history is never dropped, whatever the size. This costs one
+// slot per value of the history for each subscriber, in addition to the
+// history the signal keeps anyway. The live values are dropped like with
+// Subscribe when the consumer does not keep up. Without history nor replay,
+// SubscribeWithHistory is the same as Subscribe.
+//
+// Example:
+//
+//	signal := signals.New[Event](signals.WithHistory(100))
+//	// ...
+//	for e := range signal.SubscribeWithHistory(ctx, 16) {
+//		project(e) // The last 100 events, then the new ones
+//	}
+func (s *BaseSignal[T]) SubscribeWithHistory(ctx context.Context, bufferSize int) <-chan T {
+	return s.subscribe(ctx, bufferSize, nil, true)
+}
+
+// SubscribeWithDrops returns a channel that receives the emitted values like
+// Subscribe, along with a channel that is notified whenever a value is
+// dropped because the consumer is not keeping up, so it can adapt, for
+// instance by coalescing its work. The notifications are coalesced: the
+// dropped channel has a buffer of one, and a drop happening while a
+// notification is still pending does not add another one.
+//
+// When the context is cancelled, the underlying listener is removed and both
+// channels are closed. The values still buffered can be received before the
+// values channel reports it is closed. No goroutine is left behind. Like with
+// Subscribe, both channels are closed right away if the signal refuses the
+// listener.
+//
+// Example:
+//
+//	values, dropped := signal.SubscribeWithDrops(ctx, 16)
+//	for {
+//		select {
+//		case v, ok := <-values:
+//			if !ok {
+//				return // The context was cancelled
+//			}
+//			process(v)
+//		case <-dropped:
+//			resync() // Some values were missed
+//		}
+//	}
+func (s *BaseSignal[T]) SubscribeWithDrops(ctx context.Context, bufferSize int) (values <-chan T, dropped <-chan struct{}) {
+	drops := make(chan struct{}, 1)
+
+	return s.subscribe(ctx, bufferSize, drops, false), drops
+}
+
+// subscribe implements Subscribe. If drops is not nil, it is notified without
+// blocking of each dropped value, and closed with the values channel. If
+// history is true, the channel first receives the values of the history. The
+// channels are closed right away if the listener cannot be added.
+func (s *BaseSignal[T]) subscribe(ctx context.Context, bufferSize int, drops chan struct{}, history bool) <-chan T {
+	size := max(bufferSize, 0)
+	if history {
+		size += s.config.history
+		if s.config.history == 0 && s.config.replay {
+			size++
+		}
+	}
+	ch := make(chan T, size)
+
+	var mu sync.Mutex
+	closed := false
+	sub := newSubscriber(func(_ context.Context, payload T) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if !closed {
+			select {
+			case ch <- payload:
+			default:
+				if drops != nil {
+					select {
+					case drops <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}
+		return nil
+	}, listenerConfig{})
+	var count int
+	if history {
+		count = s.addWith(sub, func(values []T) {
+			for _, v := range values {
+				ch <- v
+			}
+		})
+	} else {
+		count = s.add(sub)
+	}
+	if count < 0 {
+		close(ch)
+		if drops != nil {
+			close(drops)
+		}
+		return ch
+	}
+
+	context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		s.delete(sub)
+		s.mu.Unlock()
+
+		mu.Lock()
+		closed = true
+		close(ch)
+		if drops != nil {
+			close(drops)
+		}
+		mu.Unlock()
+	})
+
+	return ch
+}
+
+// WaitFor blocks until the signal emits a value accepted by the predicate, and
+// returns that value. A nil predicate accepts any value. The temporary
+// listener used to wait is removed before WaitFor returns. If the context is
+// done first, WaitFor returns the zero value and the context error. If the
+// signal refuses the temporary listener, WaitFor returns ErrListenerRefused
+// right away.
+//
+// Example:
+//
+//	status, err := signal.WaitFor(ctx, func(s Status) bool {
+//		return s == Ready
+//	})
+func (s *BaseSignal[T]) WaitFor(ctx context.Context, pred func(T) bool) (T, error) {
+	values := make(chan T, 1)
+	sub := newSubscriber(func(_ context.Context, payload T) error {
+		values <- payload
+		return nil
+	}, listenerConfig{})
+	sub.filter = pred
+	sub.once = true
+	if s.add(sub) < 0 {
+		var zero T
+		return zero, ErrListenerRefused
+	}
+
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.delete(sub)
+	}()
+
+	select {
+	case v := <-values:
+		return v, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// SelectFirst blocks until one of the signals emits a value, and returns the
+// index of that signal in sigs along with the value, like a select statement
+// over the signals. A temporary listener is added to each signal, and all of
+// them are removed before SelectFirst returns, so the later emissions are not
+// observed. If several signals emit at once, only one of the values is
+// returned. If the context is done first, SelectFirst returns -1, the zero
+// value and the context error.
+//
+// Example:
+//
+//	i, v, err := signals.SelectFirst(ctx, primary, fallback)
+//	if err != nil {
+//		return err
+//	}
+//	log.Printf("signal %d emitted %v first", i, v)
+func SelectFirst[T any](ctx context.Context, sigs ...Signal[T]) (index int, value T, err error) {
+	type selected struct {
+		index int
+		value T
+	}
+	first := make(chan selected, 1)
+
+	for i, s := range sigs {
+		i := i
+		off := s.On(func(_ context.Context, payload T) {
+			select {
+			case first <- selected{index: i, value: payload}:
+			default:
+			}
+		})
+		defer off()
+	}
+
+	select {
+	case sel := <-first:
+		return sel.index, sel.value, nil
+	case <-ctx.Done():
+		var zero T
+		return -1, zero, ctx.Err()
+	}
+}
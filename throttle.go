@@ -0,0 +1,348 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ThrottledSignal is a Signal that invokes the listeners of the signal it
+// wraps at most once per interval. The first emission is delivered right
+// away and opens an interval; the emissions made during the interval collapse
+// into a single trailing emission of the latest value at the end of the
+// interval. All the other methods are provided by the wrapped signal.
+type ThrottledSignal[T any] struct {
+	Signal[T]
+
+	interval time.Duration
+
+	mu         sync.Mutex
+	timer      *time.Timer
+	generation uint64
+	pending    *pendingEmission[T]
+	closed     bool
+}
+
+// Throttle wraps the signal so its listeners are invoked at most once per
+// interval.
+//
+// Example:
+//
+//	progress := signals.Throttle(signals.New[int](), time.Second)
+//	progress.AddListener(func(ctx context.Context, percent int) {
+//	    // Called at most once per second
+//	})
+func Throttle[T any](s Signal[T], interval time.Duration) *ThrottledSignal[T] {
+	return &ThrottledSignal[T]{Signal: s, interval: interval}
+}
+
+// NewThrottled creates a new throttled signal that delivers to its listeners
+// synchronously, at most once per interval. The options are passed to
+// NewSync.
+func NewThrottled[T any](interval time.Duration, opts ...SignalOption) *ThrottledSignal[T] {
+	return Throttle(NewSync[T](opts...), interval)
+}
+
+// Emit delivers the payload right away if no interval is open, and returns
+// the error of the wrapped signal. Otherwise, it stores the payload as the
+// trailing value of the current interval, replacing any previous one, and
+// returns nil; the trailing value is delivered at the end of the interval,
+// which opens a new one.
+//
+// A trailing value is delivered with the context of the Emit call that stored
+// it, and it is dropped if that context is cancelled in the meantime. The
+// errors of trailing emissions are not reported. If the signal was closed,
+// Emit returns ErrSignalClosed.
+func (s *ThrottledSignal[T]) Emit(ctx context.Context, payload T) error {
+	stored, err := s.throttle(&pendingEmission[T]{payload: payload, ctx: ctx})
+	if stored || err != nil {
+		return err
+	}
+
+	return s.Signal.Emit(ctx, payload)
+}
+
+// EmitWithTimeout emits the payload like Emit, with a context derived from
+// ctx that is cancelled after the given timeout. A trailing value is dropped
+// if the timeout elapses before the end of the interval.
+func (s *ThrottledSignal[T]) EmitWithTimeout(ctx context.Context, payload T, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	stored, err := s.throttle(&pendingEmission[T]{payload: payload, ctx: ctx, cancel: cancel})
+	if stored {
+		return nil
+	}
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	return s.Signal.Emit(ctx, payload)
+}
+
+// EmitAndWait emits the payload like Emit. If no interval is open, it waits
+// for the listeners like the EmitAndWait method of the wrapped signal.
+// Otherwise, it returns as soon as the payload was stored as the trailing
+// value.
+func (s *ThrottledSignal[T]) EmitAndWait(ctx context.Context, payload T) error {
+	stored, err := s.throttle(&pendingEmission[T]{payload: payload, ctx: ctx})
+	if stored || err != nil {
+		return err
+	}
+
+	return s.Signal.EmitAndWait(ctx, payload)
+}
+
+// EmitWithAck emits the payload like Emit. If no interval is open, it returns
+// the acks of the listeners like the EmitWithAck method of the wrapped signal.
+// Otherwise, it returns a closed channel as soon as the payload was stored as
+// the trailing value.
+func (s *ThrottledSignal[T]) EmitWithAck(ctx context.Context, payload T) (<-chan Ack, error) {
+	stored, err := s.throttle(&pendingEmission[T]{payload: payload, ctx: ctx})
+	if stored || err != nil {
+		return closedAcks(), err
+	}
+
+	return s.Signal.EmitWithAck(ctx, payload)
+}
+
+// EmitWithPriority emits the payload like Emit. If no interval is open, it
+// uses the EmitWithPriority method of the wrapped signal with the given
+// priority. Otherwise, the payload is stored as the trailing value, which is
+// emitted without priority.
+func (s *ThrottledSignal[T]) EmitWithPriority(ctx context.Context, payload T, priority int) error {
+	stored, err := s.throttle(&pendingEmission[T]{payload: payload, ctx: ctx})
+	if stored || err != nil {
+		return err
+	}
+
+	return s.Signal.EmitWithPriority(ctx, payload, priority)
+}
+
+// EmitFunc emits the payload returned by produce like Emit, but only calls
+// produce if the wrapped signal has at least one listener. Otherwise, it
+// returns nil, or ErrNoListeners if the wrapped signal was created with
+// WithErrorOnNoListeners.
+func (s *ThrottledSignal[T]) EmitFunc(ctx context.Context, produce func() T) error {
+	if s.Signal.IsEmpty() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.closed {
+			return ErrSignalClosed
+		}
+
+		return noListeners(s.Signal)
+	}
+
+	return s.Emit(ctx, produce())
+}
+
+// EmitFactory emits like Emit the values built by factory, using the
+// EmitFactory method of the wrapped signal. A trailing emission calls factory
+// only once it is delivered. It returns ErrNotImplemented if the wrapped
+// signal has no EmitFactory method.
+func (s *ThrottledSignal[T]) EmitFactory(ctx context.Context, factory func() T) error {
+	f, ok := s.Signal.(factoryEmitter[T])
+	if !ok {
+		return ErrNotImplemented
+	}
+
+	stored, err := s.throttle(&pendingEmission[T]{ctx: ctx, factory: factory})
+	if stored || err != nil {
+		return err
+	}
+
+	return f.EmitFactory(ctx, factory)
+}
+
+// MustEmit emits the payload like Emit, and panics with the error of Emit
+// if there is one.
+func (s *ThrottledSignal[T]) MustEmit(ctx context.Context, payload T) {
+	if err := s.Emit(ctx, payload); err != nil {
+		panic(err)
+	}
+}
+
+// EmitN emits the payload like Emit. If no interval is open, it emits the
+// payload like the EmitN method of the wrapped signal and returns its count.
+// Otherwise, the payload is stored as the trailing value and the count is 0.
+// It returns ErrNotImplemented if the wrapped signal has no EmitN method.
+func (s *ThrottledSignal[T]) EmitN(ctx context.Context, payload T) (int, error) {
+	counting, ok := s.Signal.(countingEmitter[T])
+	if !ok {
+		return 0, ErrNotImplemented
+	}
+
+	stored, err := s.throttle(&pendingEmission[T]{payload: payload, ctx: ctx})
+	if stored || err != nil {
+		return 0, err
+	}
+
+	return counting.EmitN(ctx, payload)
+}
+
+// TryEmit emits the payload like Emit. If no interval is open, it tries to
+// emit the payload like the TryEmit method of the wrapped signal. Otherwise,
+// the payload is stored as the trailing value and TryEmit returns true. It
+// returns ErrNotImplemented if the wrapped signal has no TryEmit method.
+func (s *ThrottledSignal[T]) TryEmit(ctx context.Context, payload T) (bool, error) {
+	try, ok := s.Signal.(tryEmitter[T])
+	if !ok {
+		return false, ErrNotImplemented
+	}
+
+	stored, err := s.throttle(&pendingEmission[T]{payload: payload, ctx: ctx})
+	if stored || err != nil {
+		return stored, err
+	}
+
+	return try.TryEmit(ctx, payload)
+}
+
+// EmitBatch emits the values one after the other like Emit, and returns their
+// errors joined with errors.Join.
+func (s *ThrottledSignal[T]) EmitBatch(ctx context.Context, values []T) error {
+	var errs []error
+	for _, v := range values {
+		errs = append(errs, s.Emit(ctx, v))
+	}
+
+	return errors.Join(errs...)
+}
+
+// EmitMany emits the values one after the other like EmitBatch.
+func (s *ThrottledSignal[T]) EmitMany(ctx context.Context, values ...T) error {
+	return s.EmitBatch(ctx, values)
+}
+
+// CompareAndEmit emits the new value like Emit. If no interval is open, it
+// returns the result of the CompareAndEmit method of the wrapped signal.
+// Otherwise, the new value is stored as the trailing value and CompareAndEmit
+// returns true; at the end of the interval, the trailing value is emitted
+// with the CompareAndEmit method of the wrapped signal, so it is compared with
+// the value stored at that time, and the result is not reported. It returns
+// ErrNotSticky if the wrapped signal is not a StickySignal.
+func (s *ThrottledSignal[T]) CompareAndEmit(ctx context.Context, old, new T) (bool, error) {
+	sticky, ok := s.Signal.(StickySignal[T])
+	if !ok {
+		return false, ErrNotSticky
+	}
+
+	stored, err := s.throttle(&pendingEmission[T]{payload: new, ctx: ctx, swap: true, old: old})
+	if stored || err != nil {
+		return stored, err
+	}
+
+	return sticky.CompareAndEmit(ctx, old, new)
+}
+
+// EmitTagged emits the payload like Emit, using the EmitTagged method of the
+// wrapped signal with the given tags.
+func (s *ThrottledSignal[T]) EmitTagged(ctx context.Context, payload T, tags ...string) error {
+	stored, err := s.throttle(&pendingEmission[T]{payload: payload, ctx: ctx, tagged: true, tags: tags})
+	if stored || err != nil {
+		return err
+	}
+
+	return s.Signal.EmitTagged(ctx, payload, tags...)
+}
+
+// throttle stores the emission as the trailing value if an interval is open,
+// and returns true. Otherwise, it opens a new interval, and returns false to
+// let the caller deliver the emission right away. It returns ErrSignalClosed
+// if the signal was closed.
+func (s *ThrottledSignal[T]) throttle(p *pendingEmission[T]) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false, ErrSignalClosed
+	}
+
+	if s.timer != nil {
+		s.pending.discard()
+		s.pending = p
+		return true, nil
+	}
+
+	s.start()
+
+	return false, nil
+}
+
+// start opens a new interval. It must be called with the lock held.
+func (s *ThrottledSignal[T]) start() {
+	generation := s.generation
+	s.timer = time.AfterFunc(s.interval, func() {
+		s.tick(generation)
+	})
+}
+
+// tick ends the interval of the given generation. It delivers the trailing
+// value, if there is one, and opens a new interval for it.
+func (s *ThrottledSignal[T]) tick(generation uint64) {
+	s.mu.Lock()
+	if generation != s.generation {
+		s.mu.Unlock()
+		return
+	}
+
+	s.timer = nil
+	p := s.pending
+	s.pending = nil
+	if p != nil {
+		s.start()
+	}
+	s.mu.Unlock()
+
+	p.deliver(s.Signal)
+}
+
+// stop ends the current interval without delivering the trailing value, which
+// is returned. It must be called with the lock held.
+func (s *ThrottledSignal[T]) stop() *pendingEmission[T] {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.generation++
+
+	p := s.pending
+	s.pending = nil
+
+	return p
+}
+
+// Reset drops the trailing value, ends the current interval, and resets the
+// wrapped signal.
+func (s *ThrottledSignal[T]) Reset() {
+	s.mu.Lock()
+	p := s.stop()
+	s.mu.Unlock()
+
+	p.discard()
+	s.Signal.Reset()
+}
+
+// ResetAndCancel drops the trailing value, ends the current interval, and
+// resets the wrapped signal with its ResetAndCancel method.
+func (s *ThrottledSignal[T]) ResetAndCancel(ctx context.Context) error {
+	s.mu.Lock()
+	p := s.stop()
+	s.mu.Unlock()
+
+	p.discard()
+
+	return s.Signal.ResetAndCancel(ctx)
+}
+
+// Close flushes the trailing value right away, if there is one and its
+// context is not cancelled, and then closes the wrapped signal.
+func (s *ThrottledSignal[T]) Close() {
+	s.mu.Lock()
+	s.closed = true
+	p := s.stop()
+	s.mu.Unlock()
+
+	p.deliver(s.Signal)
+	s.Signal.Close()
+}